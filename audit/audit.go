@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// 操作人身份
+// 管理后台调用写接口时在 gRPC metadata 中携带操作人（x-operator），
+// 后台任务等没有请求的场景通过 WithOperator 指定操作人。
+
+const (
+	// OperatorMetadataKey 请求 metadata 中操作人的 key
+	OperatorMetadataKey = "x-operator"
+
+	// SystemOperator 无法确定操作人时使用的默认值
+	SystemOperator = "system"
+)
+
+type operatorKey struct{}
+
+// WithOperator 返回指定了操作人的 context，优先于请求 metadata 中的操作人
+func WithOperator(ctx context.Context, operator string) context.Context {
+	return context.WithValue(ctx, operatorKey{}, operator)
+}
+
+// Operator 返回当前操作人，依次从 context、请求 metadata 中获取，都没有时返回 SystemOperator
+func Operator(ctx context.Context) string {
+	if operator, ok := ctx.Value(operatorKey{}).(string); ok && operator != "" {
+		return operator
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(OperatorMetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return SystemOperator
+}
@@ -0,0 +1,167 @@
+package binlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goods_srv/config"
+	"goods_srv/dao/redis"
+	"goods_srv/logger"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/canal"
+	"github.com/go-mysql-org/go-mysql/mysql"
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/go-redsync/redsync/v4"
+	"go.uber.org/zap"
+)
+
+// binlog 订阅
+// 以从库身份消费 MySQL binlog，商品表和直播间商品表发生行变更时回调 Handler 删除对应缓存，
+// 这样运维或其他服务直接修改数据库时缓存也能及时失效。
+// 多个实例通过分布式锁选出一个 leader 消费，binlog 位点保存在 Redis 中，重启或切换 leader 后从位点继续消费。
+
+const (
+	// leaderLockKey 选主的分布式锁
+	leaderLockKey = "lock_goods_binlog_leader"
+	// positionKey 保存已消费的 binlog 位点
+	positionKey = "goods_binlog_position"
+
+	// leaderTTL 分布式锁的过期时间，leader 每 leaderTTL/3 续期一次
+	leaderTTL = 30 * time.Second
+)
+
+// 行变更类型
+const (
+	ActionInsert = canal.InsertAction
+	ActionUpdate = canal.UpdateAction
+	ActionDelete = canal.DeleteAction
+)
+
+// Handler 处理行变更
+type Handler interface {
+	// OnGoodsChanged xx_goods_query 表的行变更，更新时新旧行的 goods_id 都会回调
+	OnGoodsChanged(ctx context.Context, action string, goodsId int64) error
+	// OnRoomGoodsChanged xx_room_goods 表的行变更
+	OnRoomGoodsChanged(ctx context.Context, action string, roomId, goodsId int64) error
+}
+
+var errLostLeader = errors.New("lost binlog leader lock")
+
+// Start 启动 binlog 订阅，未开启时直接返回
+func Start(ctx context.Context, h Handler) {
+	cfg := config.Conf.BinlogConfig
+	if cfg == nil || !cfg.Enabled {
+		return
+	}
+	go campaignLoop(ctx, h)
+}
+
+// campaignLoop 竞选 leader，成为 leader 后开始消费 binlog，失去 leader 后重新竞选
+func campaignLoop(ctx context.Context, h Handler) {
+	for ctx.Err() == nil {
+		mutex := redis.Rs.NewMutex(leaderLockKey, redsync.WithExpiry(leaderTTL), redsync.WithTries(1))
+		if err := mutex.LockContext(ctx); err != nil {
+			// 其他实例正在消费
+			time.Sleep(leaderTTL / 3)
+			continue
+		}
+		logger.FromContext(ctx).Info("Became binlog leader, start syncing binlog")
+		err := runAsLeader(ctx, mutex, h)
+		logger.FromContext(ctx).Warn("Stop syncing binlog", zap.Error(err))
+		mutex.UnlockContext(context.Background())
+		time.Sleep(time.Second)
+	}
+}
+
+// runAsLeader 从保存的位点开始消费 binlog，直到出错或失去 leader
+func runAsLeader(ctx context.Context, mutex *redsync.Mutex, h Handler) error {
+	c, err := newCanal(ctx, h)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	pos, err := loadPosition(ctx)
+	if err != nil {
+		return err
+	}
+	if pos == nil {
+		// 第一次启动没有位点，从当前位置开始消费，不做全量同步
+		masterPos, err := c.GetMasterPos()
+		if err != nil {
+			return err
+		}
+		pos = &masterPos
+	}
+	logger.FromContext(ctx).Info("Binlog sync from position", zap.Stringer("pos", pos))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.RunFrom(*pos)
+	}()
+
+	ticker := time.NewTicker(leaderTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errCh:
+			return err
+		case <-ticker.C:
+			if ok, err := mutex.ExtendContext(ctx); err != nil || !ok {
+				return errLostLeader
+			}
+		}
+	}
+}
+
+// newCanal 创建只订阅商品表和直播间商品表的 canal 实例
+func newCanal(ctx context.Context, h Handler) (*canal.Canal, error) {
+	mysqlCfg := config.Conf.MySQLConfig
+	cfg := canal.NewDefaultConfig()
+	cfg.Addr = fmt.Sprintf("%s:%d", mysqlCfg.Host, mysqlCfg.Port)
+	cfg.User = mysqlCfg.User
+	cfg.Password = mysqlCfg.Password
+	cfg.ServerID = config.Conf.BinlogConfig.ServerID
+	cfg.IncludeTableRegex = []string{
+		fmt.Sprintf("%s\\.%s", mysqlCfg.DB, goodsTable),
+		fmt.Sprintf("%s\\.%s", mysqlCfg.DB, roomGoodsTable),
+	}
+	// 不使用 mysqldump 做全量同步，只消费增量 binlog
+	cfg.Dump.ExecutionPath = ""
+
+	c, err := canal.NewCanal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.SetEventHandler(&eventHandler{ctx: ctx, h: h})
+	return c, nil
+}
+
+// loadPosition 读取保存的 binlog 位点，不存在时返回 nil
+func loadPosition(ctx context.Context) (*mysql.Position, error) {
+	data, err := redis.GetClient().Get(ctx, positionKey).Bytes()
+	if err == goredis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pos mysql.Position
+	if err := json.Unmarshal(data, &pos); err != nil {
+		return nil, err
+	}
+	return &pos, nil
+}
+
+// savePosition 保存已消费的 binlog 位点
+func savePosition(ctx context.Context, pos mysql.Position) error {
+	data, err := json.Marshal(pos)
+	if err != nil {
+		return err
+	}
+	return redis.GetClient().Set(ctx, positionKey, data, 0).Err()
+}
@@ -0,0 +1,126 @@
+package binlog
+
+import (
+	"context"
+	"fmt"
+	"goods_srv/logger"
+	"goods_srv/model"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/canal"
+	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/go-mysql-org/go-mysql/replication"
+	"go.uber.org/zap"
+)
+
+var (
+	goodsTable     = model.Goods{}.TableName()
+	roomGoodsTable = model.RoomGoods{}.TableName()
+)
+
+const (
+	// 回调失败时的重试次数和退避间隔
+	// 重试后仍然失败时返回错误停止消费，不保存之后的位点，重新成为 leader 后从上次保存的位点重新消费
+	handleRetries    = 5
+	handleMinBackoff = 100 * time.Millisecond
+	handleMaxBackoff = 5 * time.Second
+)
+
+// eventHandler 将 canal 的行事件转换为 Handler 回调
+type eventHandler struct {
+	canal.DummyEventHandler
+	ctx context.Context
+	h   Handler
+}
+
+// OnRow 行变更回调删除缓存，重试后仍然失败时返回错误，canal 停止消费并且不会保存之后的位点
+func (e *eventHandler) OnRow(ev *canal.RowsEvent) error {
+	switch ev.Table.Name {
+	case goodsTable:
+		goodsCol := ev.Table.FindColumn("goods_id")
+		seen := make(map[int64]bool, len(ev.Rows))
+		for _, row := range ev.Rows {
+			goodsId := toInt64(row[goodsCol])
+			if seen[goodsId] {
+				continue
+			}
+			seen[goodsId] = true
+			err := e.retry(func() error {
+				return e.h.OnGoodsChanged(e.ctx, ev.Action, goodsId)
+			})
+			if err != nil {
+				return fmt.Errorf("handle goods binlog, GoodsId: %d: %w", goodsId, err)
+			}
+		}
+	case roomGoodsTable:
+		roomCol, goodsCol := ev.Table.FindColumn("room_id"), ev.Table.FindColumn("goods_id")
+		for _, row := range ev.Rows {
+			roomId, goodsId := toInt64(row[roomCol]), toInt64(row[goodsCol])
+			err := e.retry(func() error {
+				return e.h.OnRoomGoodsChanged(e.ctx, ev.Action, roomId, goodsId)
+			})
+			if err != nil {
+				return fmt.Errorf("handle room goods binlog, RoomId: %d GoodsId: %d: %w", roomId, goodsId, err)
+			}
+		}
+	}
+	return nil
+}
+
+// retry 调用回调，失败时按指数退避重试
+func (e *eventHandler) retry(fn func() error) error {
+	backoff := handleMinBackoff
+	for i := 0; ; i++ {
+		err := fn()
+		if err == nil || i == handleRetries {
+			return err
+		}
+		logger.FromContext(e.ctx).Warn("Retry binlog handler", zap.Int("attempt", i+1), zap.Error(err))
+		select {
+		case <-e.ctx.Done():
+			return e.ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, handleMaxBackoff)
+	}
+}
+
+// OnPosSynced 保存位点，只有在这之前的行事件全部处理成功时 canal 才会继续调用
+// 保存失败时只会在重新消费时重复删除缓存，不影响一致性
+func (e *eventHandler) OnPosSynced(header *replication.EventHeader, pos mysql.Position, set mysql.GTIDSet, force bool) error {
+	if err := savePosition(e.ctx, pos); err != nil {
+		logger.FromContext(e.ctx).Error("Failed to save binlog position", zap.Stringer("pos", pos), zap.Error(err))
+	}
+	return nil
+}
+
+func (e *eventHandler) String() string {
+	return "GoodsBinlogHandler"
+}
+
+// toInt64 将 binlog 中的整数列转换为 int64
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case int8:
+		return int64(n)
+	case int16:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	case uint8:
+		return int64(n)
+	case uint16:
+		return int64(n)
+	case uint32:
+		return int64(n)
+	case uint64:
+		return int64(n)
+	case int:
+		return int64(n)
+	case uint:
+		return int64(n)
+	}
+	return 0
+}
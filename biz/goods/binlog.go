@@ -0,0 +1,32 @@
+package goods
+
+import (
+	"context"
+	"goods_srv/binlog"
+	"goods_srv/bloomfilter"
+	"goods_srv/cachebus"
+	"goods_srv/logger"
+
+	"go.uber.org/zap"
+)
+
+// BinlogHandler 处理 binlog 行变更，删除对应的缓存
+type BinlogHandler struct{}
+
+var _ binlog.Handler = BinlogHandler{}
+
+// OnGoodsChanged 商品表变更，新增商品加入布隆过滤器，并删除商品详情缓存
+func (BinlogHandler) OnGoodsChanged(ctx context.Context, action string, goodsId int64) error {
+	if action == binlog.ActionInsert {
+		bloomfilter.Add(ctx, goodsId)
+		if err := cachebus.PublishNewGoods(ctx, goodsId); err != nil {
+			logger.FromContext(ctx).Error("Failed to publish new goods", zap.Int64("goods_id", goodsId), zap.Error(err))
+		}
+	}
+	return InvalidateGoodsCache(ctx, goodsId)
+}
+
+// OnRoomGoodsChanged 直播间商品表变更，删除直播间商品列表缓存
+func (BinlogHandler) OnRoomGoodsChanged(ctx context.Context, action string, roomId, goodsId int64) error {
+	return InvalidateRoomCache(ctx, roomId)
+}
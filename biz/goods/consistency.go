@@ -0,0 +1,127 @@
+package goods
+
+import (
+	"context"
+	"goods_srv/cachebus"
+	"goods_srv/config"
+	"goods_srv/dao/mysql"
+	"goods_srv/dao/redis"
+	"goods_srv/logger"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// 商品写操作后的缓存一致性策略
+const (
+	// StrategyDelete 更新数据库后删除缓存（默认）
+	StrategyDelete = "delete"
+	// StrategyDelayedDoubleDelete 更新数据库后立即删除缓存，延迟一段时间后再删除一次，
+	// 清理并发读请求在删除之后写回的旧数据
+	StrategyDelayedDoubleDelete = "delayed_double_delete"
+	// StrategyWriteThrough 更新数据库后从数据库读取最新数据写入缓存
+	StrategyWriteThrough = "write_through"
+	// StrategyVersioned 在 write_through 的基础上，缓存写入时比较 BaseModel.Version，
+	// 旧版本的数据无法覆盖新版本
+	StrategyVersioned = "versioned"
+
+	// defaultDoubleDeleteDelay 未配置时延迟双删的默认间隔，需大于一次读请求回源的耗时
+	defaultDoubleDeleteDelay = time.Second
+)
+
+// setIfNewerScript 缓存中的版本号比要写入的版本号新时不写入，避免旧数据覆盖新数据
+// 版本号达到 65535 后回绕到 0，按序号算术比较：缓存中的版本号领先不到半圈（32768）时认为更新，
+// 因此 65535 之后的 0 比 65535 新
+// KEYS[1] 数据 key，KEYS[2] 版本号 key；ARGV[1] 数据，ARGV[2] 版本号，ARGV[3] 过期时间（毫秒）
+var setIfNewerScript = goredis.NewScript(`
+local cur = redis.call('GET', KEYS[2])
+if cur then
+	local ahead = (tonumber(cur) - tonumber(ARGV[2])) % 65536
+	if ahead > 0 and ahead < 32768 then
+		return 0
+	end
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+
+// writeStrategy 返回配置的一致性策略
+func writeStrategy() string {
+	if cfg := config.Conf.CacheConfig; cfg != nil && cfg.WriteStrategy != "" {
+		return cfg.WriteStrategy
+	}
+	return StrategyDelete
+}
+
+// doubleDeleteDelay 返回配置的延迟双删间隔
+func doubleDeleteDelay() time.Duration {
+	if cfg := config.Conf.CacheConfig; cfg != nil && cfg.DoubleDeleteDelay > 0 {
+		return cfg.DoubleDeleteDelay
+	}
+	return defaultDoubleDeleteDelay
+}
+
+// versionKey 商品详情缓存对应的版本号 key
+func versionKey(cacheKey string) string {
+	return cacheKey + "_ver"
+}
+
+// setIfNewerVersion 版本号不比缓存中的版本号旧时写入 Redis，返回是否写入
+func setIfNewerVersion(ctx context.Context, cacheKey string, data []byte, version uint16, ttl time.Duration) (bool, error) {
+	n, err := setIfNewerScript.Run(ctx, redis.GetClient(),
+		[]string{cacheKey, versionKey(cacheKey)},
+		data, version, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// syncGoodsCacheAfterWrite 商品写入数据库后按配置的一致性策略处理缓存
+func syncGoodsCacheAfterWrite(ctx context.Context, goodsId int64) error {
+	switch writeStrategy() {
+	case StrategyDelayedDoubleDelete:
+		if err := InvalidateGoodsCache(ctx, goodsId); err != nil {
+			return err
+		}
+		// 第二次删除在后台进行，不阻塞写请求
+		l := logger.FromContext(ctx)
+		time.AfterFunc(doubleDeleteDelay(), func() {
+			if err := InvalidateGoodsCache(context.Background(), goodsId); err != nil {
+				l.Error("Failed to delete cache in double delete", zap.Int64("goods_id", goodsId), zap.Error(err))
+			}
+		})
+		return nil
+	case StrategyWriteThrough, StrategyVersioned:
+		return refreshGoodsCache(ctx, goodsId)
+	default:
+		return InvalidateGoodsCache(ctx, goodsId)
+	}
+}
+
+// refreshGoodsCache 从数据库读取最新的商品详情写入缓存，并通知其他实例删除本地缓存
+func refreshGoodsCache(ctx context.Context, goodsId int64) error {
+	goodsDetail, err := mysql.GetGoodsDetailById(ctx, goodsId)
+	if err != nil {
+		// 读取失败时退化为删除缓存
+		logger.FromContext(ctx).Warn("Failed to reload goods detail, fall back to delete", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return InvalidateGoodsCache(ctx, goodsId)
+	}
+	if goodsDetail == nil {
+		return InvalidateGoodsCache(ctx, goodsId)
+	}
+
+	cacheKey := goodsDetailCacheKey(goodsId)
+	// 先删除本地缓存，避免版本号写入被跳过时本实例继续返回旧数据
+	localCache.Delete(cacheKey)
+	if err := setGoodsDetailCache(ctx, cacheKey, toGoodsDetailProto(goodsDetail), goodsDetail.Version); err != nil {
+		return err
+	}
+	// 其他实例删除本地缓存后会从 Redis 读到最新数据
+	if err := cachebus.Publish(ctx, cacheKey); err != nil {
+		logger.FromContext(ctx).Error("Failed to publish cache invalidation", zap.String("key", cacheKey), zap.Error(err))
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package goods
+
+import (
+	"context"
+	"goods_srv/cachecodec"
+	"goods_srv/config"
+	"goods_srv/dao/mysql"
+	"goods_srv/dao/redis"
+	"goods_srv/localcache"
+	"goods_srv/model"
+	"goods_srv/proto"
+	"math"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// 缓存一致性策略的并发测试
+// Redis 使用 miniredis，MySQL 使用 SQLite 文件数据库，测试更新商品与并发的回源写缓存交错时缓存中最终的数据
+
+const testGoodsId int64 = 1001
+
+// setupConsistencyTest 初始化 Redis、数据库和本地缓存，使用指定的一致性策略，并创建一个价格为 100.00 的商品
+func setupConsistencyTest(t *testing.T, strategy string) (*miniredis.Miniredis, *gorm.DB) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	port, err := strconv.Atoi(mr.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := redis.Init(&config.RedisConfig{Host: mr.Host(), Port: port}); err != nil {
+		t.Fatalf("init redis: %v", err)
+	}
+
+	gdb, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "goods.db")), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SQLite 不支持并发写，所有请求共用一个连接排队执行
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := gdb.AutoMigrate(&model.Goods{}, &model.GoodsChangeLog{}, &model.RoomGoods{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	mysql.InitWithDB(gdb)
+
+	oldConf := config.Conf
+	config.Conf = &config.SrvConfig{
+		CacheConfig: &config.CacheConfig{
+			WriteStrategy:     strategy,
+			DoubleDeleteDelay: 50 * time.Millisecond,
+		},
+	}
+	t.Cleanup(func() { config.Conf = oldConf })
+
+	localCache = localcache.New(localcache.Options{})
+	staleCache = localcache.New(localcache.Options{})
+
+	err = mysql.CreateGoods(context.Background(), &model.Goods{
+		GoodsId:     testGoodsId,
+		CategoryId:  1,
+		BrandName:   "brand",
+		Code:        "G1001",
+		Title:       "测试商品",
+		MarketPrice: 12000,
+		Price:       10000,
+	}, mysql.ChangeInfo{Operator: "test"})
+	if err != nil {
+		t.Fatalf("create goods: %v", err)
+	}
+	return mr, gdb
+}
+
+// cachedGoodsDetail 读取 Redis 中的商品详情缓存和缓存记录的版本号，ok 为 false 表示没有缓存
+func cachedGoodsDetail(t *testing.T, mr *miniredis.Miniredis, goodsId int64) (*proto.GoodsDetail, int64, bool) {
+	t.Helper()
+	data, err := mr.Get(goodsDetailCacheKey(goodsId))
+	if err == miniredis.ErrKeyNotFound {
+		return nil, 0, false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var detail proto.GoodsDetail
+	meta, err := cachecodec.Unmarshal([]byte(data), &detail)
+	if err != nil {
+		t.Fatalf("decode cache: %v", err)
+	}
+	return &detail, meta.RowVersion, true
+}
+
+// waitCacheDeleted 等待延迟双删的第二次删除执行完成
+func waitCacheDeleted(t *testing.T, mr *miniredis.Miniredis, goodsId int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for mr.Exists(goodsDetailCacheKey(goodsId)) {
+		if time.Now().After(deadline) {
+			t.Fatal("cache not deleted by the delayed second delete")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestWriteStrategyStaleRepopulate 读请求在更新前读到旧数据，在更新处理完缓存之后才写回缓存
+// 删除和 write_through 策略会留下旧数据，延迟双删和版本号策略最终缓存的是新数据
+func TestWriteStrategyStaleRepopulate(t *testing.T) {
+	tests := []struct {
+		strategy  string
+		wantStale bool // 缓存中最终是否为旧数据
+	}{
+		{StrategyDelete, true},
+		{StrategyDelayedDoubleDelete, false},
+		{StrategyWriteThrough, true},
+		{StrategyVersioned, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			mr, _ := setupConsistencyTest(t, tt.strategy)
+			ctx := context.Background()
+			cacheKey := goodsDetailCacheKey(testGoodsId)
+
+			// 1. 读请求缓存未命中，从数据库读到旧数据
+			stale, err := mysql.GetGoodsDetailById(ctx, testGoodsId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 2. 更新商品价格，并按策略处理缓存
+			if _, err := UpdateGoodsDetail(ctx, testGoodsId, 20000, nil, "test"); err != nil {
+				t.Fatalf("update goods: %v", err)
+			}
+			fresh, err := mysql.GetGoodsDetailById(ctx, testGoodsId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fresh.Version <= stale.Version {
+				t.Fatalf("version not increased: %d -> %d", stale.Version, fresh.Version)
+			}
+
+			// 3. 读请求把旧数据写回缓存
+			if err := setGoodsDetailCache(ctx, cacheKey, toGoodsDetailProto(stale), stale.Version); err != nil {
+				t.Fatalf("repopulate cache: %v", err)
+			}
+
+			// 4. 延迟双删的第二次删除清理旧数据，之后的读请求重新回源
+			if tt.strategy == StrategyDelayedDoubleDelete {
+				waitCacheDeleted(t, mr, testGoodsId)
+				if _, err := GetGoodsDetailById(ctx, testGoodsId); err != nil {
+					t.Fatalf("get goods detail: %v", err)
+				}
+			}
+
+			want := fresh
+			if tt.wantStale {
+				want = stale
+			}
+			detail, version, ok := cachedGoodsDetail(t, mr, testGoodsId)
+			if !ok {
+				t.Fatal("goods detail not cached")
+			}
+			if version != int64(want.Version) {
+				t.Errorf("cached version = %d, want %d", version, want.Version)
+			}
+			if wantPrice := toGoodsDetailProto(want).Price; detail.Price != wantPrice {
+				t.Errorf("cached price = %s, want %s", detail.Price, wantPrice)
+			}
+		})
+	}
+}
+
+// TestVersionedConcurrentUpdates 多个读请求不断回源写缓存，同时连续更新商品价格
+// 版本号策略下旧版本无法覆盖新版本，更新结束后缓存中是数据库的最新数据
+func TestVersionedConcurrentUpdates(t *testing.T) {
+	mr, _ := setupConsistencyTest(t, StrategyVersioned)
+	ctx := context.Background()
+	cacheKey := goodsDetailCacheKey(testGoodsId)
+
+	const (
+		readers = 8
+		updates = 20
+	)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				goods, err := mysql.GetGoodsDetailById(ctx, testGoodsId)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				// 读到数据后让出调度，让更新有机会插入到读数据库和写缓存之间
+				time.Sleep(time.Millisecond)
+				if err := setGoodsDetailCache(ctx, cacheKey, toGoodsDetailProto(goods), goods.Version); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	var updateErr error
+	for i := 1; i <= updates && updateErr == nil; i++ {
+		_, updateErr = UpdateGoodsDetail(ctx, testGoodsId, int64(10000+i*100), nil, "test")
+	}
+	close(done)
+	wg.Wait()
+	if updateErr != nil {
+		t.Fatalf("update goods: %v", updateErr)
+	}
+
+	latest, err := mysql.GetGoodsDetailById(ctx, testGoodsId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail, version, ok := cachedGoodsDetail(t, mr, testGoodsId)
+	if !ok {
+		t.Fatal("goods detail not cached")
+	}
+	if version != int64(latest.Version) {
+		t.Errorf("cached version = %d, want %d", version, latest.Version)
+	}
+	if wantPrice := toGoodsDetailProto(latest).Price; detail.Price != wantPrice {
+		t.Errorf("cached price = %s, want %s", detail.Price, wantPrice)
+	}
+}
+
+// TestVersionWrap 版本号达到 65535 后更新回绕到 0，版本号策略下回绕后的新数据可以覆盖缓存，旧数据不能
+func TestVersionWrap(t *testing.T) {
+	mr, gdb := setupConsistencyTest(t, StrategyVersioned)
+	ctx := context.Background()
+	cacheKey := goodsDetailCacheKey(testGoodsId)
+
+	err := gdb.Model(&model.Goods{}).Where("goods_id = ?", testGoodsId).Update("version", math.MaxUint16).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := mysql.GetGoodsDetailById(ctx, testGoodsId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if old.Version != math.MaxUint16 {
+		t.Fatalf("version = %d, want %d", old.Version, math.MaxUint16)
+	}
+	if _, err := GetGoodsDetailById(ctx, testGoodsId); err != nil {
+		t.Fatalf("get goods detail: %v", err)
+	}
+
+	// 版本号为 65535 时仍然可以更新，更新后回绕到 0
+	if _, err := UpdateGoodsDetail(ctx, testGoodsId, 20000, nil, "test"); err != nil {
+		t.Fatalf("update goods at max version: %v", err)
+	}
+	fresh, err := mysql.GetGoodsDetailById(ctx, testGoodsId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.Version != 0 {
+		t.Fatalf("version after wrap = %d, want 0", fresh.Version)
+	}
+	// 回绕后的版本号仍然可以按期望版本号更新
+	expected := fresh.Version
+	if _, err := UpdateGoodsDetail(ctx, testGoodsId, 30000, &expected, "test"); err != nil {
+		t.Fatalf("update goods after wrap: %v", err)
+	}
+	fresh, err = mysql.GetGoodsDetailById(ctx, testGoodsId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 回绕前的旧数据不能覆盖回绕后的新数据
+	if err := setGoodsDetailCache(ctx, cacheKey, toGoodsDetailProto(old), old.Version); err != nil {
+		t.Fatalf("repopulate cache: %v", err)
+	}
+	detail, version, ok := cachedGoodsDetail(t, mr, testGoodsId)
+	if !ok {
+		t.Fatal("goods detail not cached")
+	}
+	if version != int64(fresh.Version) {
+		t.Errorf("cached version = %d, want %d", version, fresh.Version)
+	}
+	if wantPrice := toGoodsDetailProto(fresh).Price; detail.Price != wantPrice {
+		t.Errorf("cached price = %s, want %s", detail.Price, wantPrice)
+	}
+}
@@ -2,27 +2,38 @@ package goods
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"goods_srv/bloomfilter"
+	"goods_srv/cachebus"
+	"goods_srv/cachecodec"
+	"goods_srv/config"
 	"goods_srv/dao/mysql"
 	"goods_srv/dao/redis"
 	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/model"
 	"goods_srv/proto"
-	"log"
 	"math/rand"
-	"sync"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 // biz层业务代码
 // biz -> dao
 
-var localCache = &sync.Map{}
+// tombstoneValue 空值缓存（墓碑）的占位值，表示数据库中已确认不存在该商品
+const tombstoneValue = "__tombstone__"
+
+// defaultTombstoneTTL 未配置时空值缓存的默认过期时间
+const defaultTombstoneTTL = time.Minute
 
 // GetRoomGoodsListProto 根据直播间 ID 查询直播间绑定的所有商品信息，并组装成 protobuf 响应对象返回
-func GetGoodsByRoom(ctx context.Context, roomId int64) (*proto.GoodsListResp, error) {
-	// 1. 先去 xx_room_goods 表，根据 room_id 查询出所有的 goods_id
-	objList, err := mysql.GetGoodsByRoomId(ctx, roomId)
+// includeUnsellable 为 false 时过滤不可售（草稿、下架、审核中）的商品，管理后台传 true 查看全部商品
+func GetGoodsByRoom(ctx context.Context, roomId int64, includeUnsellable bool) (*proto.GoodsListResp, error) {
+	// 1. 先查询直播间绑定的商品 ID 和当前正在讲解的商品 ID（带缓存）
+	binding, stale, err := getRoomBinding(ctx, roomId)
 	if err != nil {
 		return nil, err // 如果查询失败，直接返回错误
 	}
@@ -31,36 +42,53 @@ func GetGoodsByRoom(ctx context.Context, roomId int64) (*proto.GoodsListResp, er
 	// 1. 拿出所有的商品 ID
 	// 2. 记住当前正在讲解的商品 ID
 	var (
-		currGoodsId int64                            // 当前正在讲解的商品 ID
-		idList      = make([]int64, 0, len(objList)) // 存储所有商品 ID 的切片
+		currGoodsId int64                                     // 当前正在讲解的商品 ID
+		idList      = make([]int64, 0, len(binding.GoodsIds)) // 存储所有商品 ID 的切片
 	)
 
-	// 遍历查询结果，提取商品 ID 和当前讲解的商品 ID
-	for _, obj := range objList {
-		fmt.Printf("obj:%#v\n", obj)         // 打印当前对象信息（调试用）
-		idList = append(idList, obj.GoodsId) // 将商品 ID 添加到 idList 中
-		if obj.IsCurrent == 1 {              // 如果当前对象是正在讲解的商品
-			currGoodsId = obj.GoodsId // 记录当前正在讲解的商品 ID
+	// 遍历绑定关系，过滤布隆过滤器判定一定不存在的商品
+	for _, goodsId := range binding.GoodsIds {
+		if !bloomfilter.MightContain(ctx, goodsId) {
+			logger.FromContext(ctx).Debug("Bloom filter rejected goods", zap.Int64("goods_id", goodsId), zap.Int64("room_id", roomId))
+			continue
 		}
+		idList = append(idList, goodsId) // 将商品 ID 添加到 idList 中
 	}
 
-	// 2. 再拿上面获取到的 goods_id 去 xx_goods 表查询所有的商品详细信息
-	goodsList, err := mysql.GetGoodsByIdList(ctx, idList)
+	// 直播间绑定了商品，但全部被布隆过滤器判定为不存在
+	if len(binding.GoodsIds) > 0 && len(idList) == 0 {
+		return nil, errno.ErrGoodsNotExist
+	}
+
+	// 2. 再批量获取商品详情，依次查询本地缓存、Redis 和数据库
+	// 直播间列表由商品详情缓存组装，商品更新后列表自然是最新的
+	details, err := getGoodsDetails(ctx, idList)
 	if err != nil {
 		return nil, err // 如果查询失败，直接返回错误
 	}
-
-	// 拼装响应数据
-	data := make([]*proto.GoodsInfo, 0, len(goodsList)) // 创建一个存储商品信息的切片
-	for _, goods := range goodsList {
+	stale = stale || details.stale
+
+	// 拼装响应数据，保持直播间内的排序
+	data := make([]*proto.GoodsInfo, 0, len(idList)) // 创建一个存储商品信息的切片
+	for _, goodsId := range idList {
+		goods, ok := details.found[goodsId]
+		if !ok {
+			continue // 商品已不存在，或存储故障且没有旧数据
+		}
+		if !includeUnsellable && goods.Status != proto.GoodsStatus_GOODS_STATUS_ON_SHELF {
+			continue // 商品不可售
+		}
+		if goodsId == binding.CurrentGoodsId {
+			currGoodsId = goodsId // 记录当前正在讲解的商品 ID
+		}
 		data = append(data, &proto.GoodsInfo{ // 创建一个 GoodsInfo 对象并添加到 data 切片中
-			GoodsId:     goods.GoodsId,                                       // 商品 ID
-			CategoryId:  goods.CategoryId,                                    // 商品分类 ID
-			Status:      int32(goods.Status),                                 // 商品状态
-			Title:       goods.Title,                                         // 商品标题
-			MarketPrice: fmt.Sprintf("%.2f", float64(goods.MarketPrice/100)), // 商品市场价（单位转换为元）
-			Price:       fmt.Sprintf("%.2f", float64(goods.Price/100)),       // 商品售价（单位转换为元）
-			Brief:       goods.Brief,                                         // 商品简介
+			GoodsId:     goods.GoodsId,     // 商品 ID
+			CategoryId:  goods.CategoryId,  // 商品分类 ID
+			Status:      goods.Status,      // 商品状态
+			Title:       goods.Title,       // 商品标题
+			MarketPrice: goods.MarketPrice, // 商品市场价（元）
+			Price:       goods.Price,       // 商品售价（元）
+			Brief:       goods.Brief,       // 商品简介
 		})
 	}
 
@@ -68,91 +96,173 @@ func GetGoodsByRoom(ctx context.Context, roomId int64) (*proto.GoodsListResp, er
 	resp := &proto.GoodsListResp{
 		CurrentGoodsId: currGoodsId, // 当前正在讲解的商品 ID
 		Data:           data,        // 商品信息列表
+		Stale:          stale,       // 是否为降级返回的旧数据
 	}
 	return resp, nil
 }
 
 func GetGoodsDetailById(ctx context.Context, goodsId int64) (*proto.GoodsDetail, error) {
+	// 0. 布隆过滤器判定商品一定不存在时直接返回，避免缓存穿透
+	if !bloomfilter.MightContain(ctx, goodsId) {
+		logger.FromContext(ctx).Debug("Bloom filter rejected goods", zap.Int64("goods_id", goodsId))
+		return nil, errno.ErrGoodsNotExist
+	}
+
 	// 构造缓存键
-	cacheKey := fmt.Sprintf("goods_detail_%d", goodsId)
+	cacheKey := goodsDetailCacheKey(goodsId)
+
 
+	// 统计请求频率，热点商品会固定在本地缓存中
+	hot := hotKeys.Record(cacheKey)
 
 	//1.首先尝试从本地缓存中获取数据
-	if localCacheData,ok := localCache.Load(cacheKey);ok{
-		log.Printf("Local cache hit:%d", goodsId)
+	if localCacheData,ok := localCache.Get(cacheKey);ok{
+		logger.FromContext(ctx).Debug("Local cache hit", zap.Int64("goods_id", goodsId))
+		if hot {
+			localCache.Pin(cacheKey)
+		}
+		if localCacheData == tombstoneValue {
+			return nil, errno.ErrGoodsDetailNull
+		}
 		return localCacheData.(*proto.GoodsDetail), nil
 	}
 	// 2. 首先尝试从 Redis 缓存中获取数据
+	if goodsDetail, hit, err := getGoodsDetailFromRedis(ctx, cacheKey); hit {
+		if err == nil && hot {
+			pinLocalCache(cacheKey, goodsDetail)
+		}
+		return goodsDetail, err
+	}
+
+	// 3. 缓存未命中，同一商品的并发请求合并为一次回源
+	resp, err := loadGoodsDetail(ctx, goodsId, cacheKey)
+	if err != nil {
+		// 存储故障时降级返回旧数据，并在后台重新回源
+		if canServeStale(err) {
+			if stale, ok := getStaleGoodsDetail(ctx, cacheKey); ok {
+				logger.FromContext(ctx).Warn("Serving stale goods detail", zap.Int64("goods_id", goodsId), zap.Error(err))
+				revalidateGoodsDetail(goodsId, cacheKey)
+				return stale, nil
+			}
+		}
+		return nil, err
+	}
+	if hot {
+		pinLocalCache(cacheKey, resp)
+	}
+
+	// 返回商品详情响应
+	logger.FromContext(ctx).Debug("Returning goods detail response", zap.Any("resp", resp))
+	return resp, nil
+}
+
+// UpdateGoodsDetail 更新商品详情，并删除缓存
+// expectedVersion 不为 nil 时与数据库中的版本号比较，不一致时返回 VersionConflictError
+func UpdateGoodsDetail(ctx context.Context, goodsId int64, newPrice int64, expectedVersion *uint16, reason string) (*proto.Response, error) {
+	// 1. 更新数据库，并记录修改日志
+	err := mysql.UpdateGoodsDetail(ctx, goodsId, newPrice, expectedVersion, changeInfo(ctx, reason))
+	if errors.Is(err, errno.ErrVersionConflict) || errors.Is(err, errno.ErrGoodsDetailNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to update goods detail", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, errno.ErrUpdateFailed
+	}
+
+	// 2. 按配置的一致性策略删除或更新缓存
+	err = syncGoodsCacheAfterWrite(ctx, goodsId)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to delete cache", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, errno.ErrCacheDeleteFailed
+	}
+
+	logger.FromContext(ctx).Info("Cache synced", zap.Int64("goods_id", goodsId))
+
+	// 3. 商品写入成功，确保其在布隆过滤器中
+	bloomfilter.Add(ctx, goodsId)
+
+	// 4. 通知订阅了直播间的客户端
+	publishPriceChanged(ctx, goodsId, newPrice)
+	return &proto.Response{}, nil
+}
+
+// getGoodsDetailFromRedis 从 Redis 缓存中读取商品详情，hit 表示是否命中（包括空值缓存）
+func getGoodsDetailFromRedis(ctx context.Context, cacheKey string) (*proto.GoodsDetail, bool, error) {
 	cachedData, err := redis.GetClient().Get(ctx, cacheKey).Result()
-	if err == nil && cachedData != "" {
+	if err == nil && cachedData == tombstoneValue {
+		// 命中空值缓存，商品已确认不存在
+		logger.FromContext(ctx).Debug("Tombstone hit", zap.String("key", cacheKey))
+		setLocalCache(cacheKey, tombstoneValue, tombstoneTTL())
+		return nil, true, errno.ErrGoodsDetailNull
+	} else if err == nil && cachedData != "" {
 		// 缓存命中
-		log.Printf("Cache hit for GoodsId: %d", goodsId)
-		var goodsDetail proto.GoodsDetail
-		// 将缓存中的 JSON 数据反序列化为 GoodsDetail 结构体
-		if err := json.Unmarshal([]byte(cachedData), &goodsDetail); err != nil {
-			log.Printf("Failed to unmarshal cached data: %v", err)
-			return nil, errno.ErrQueryFailed
+		logger.FromContext(ctx).Debug("Cache hit", zap.String("key", cacheKey))
+		goodsDetail, err := decodeGoodsDetail(cachedData)
+		if err != nil {
+			// 不兼容或损坏的缓存直接丢弃，按未命中重新回源
+			discardCacheEntry(ctx, cacheKey, err)
+			return nil, false, nil
 		}
-		return &goodsDetail, nil
+		return goodsDetail, true, nil
 	} else if err != nil {
 		// 如果从 Redis 获取数据失败，记录日志
-		log.Printf("Failed to get data from cache: %v", err)
+		logger.FromContext(ctx).Error("Failed to get data from cache", zap.String("key", cacheKey), zap.Error(err))
 	} else {
 		// 缓存未命中
-		log.Printf("Cache miss for GoodsId: %d", goodsId)
+		logger.FromContext(ctx).Debug("Cache miss", zap.String("key", cacheKey))
 	}
+	return nil, false, nil
+}
 
-	// 缓存未命中，从数据库中查询数据
-	// 1. 使用商品 ID 从 MySQL 数据库中查询商品详情
-
-	// 构造分布式锁的 key。
-	mutexname := fmt.Sprintf("lock_goods_detail_%d", goodsId)
-
-	// 创建 Redis 分布式锁。
-	mutex := redis.Rs.NewMutex(mutexname)
-
-	// 尝试获取锁。
-	if err := mutex.Lock(); err != nil {
-		return nil, errno.ErrGetLockFailed
-	}
-	defer mutex.Unlock() // 确保在函数结束时释放锁。
+// goodsDetailCacheKey 商品详情的缓存 key
+func goodsDetailCacheKey(goodsId int64) string {
+	return fmt.Sprintf("goods_detail_%d", goodsId)
+}
 
-	goodsDetail, err := mysql.GetGoodsDetailById(ctx, goodsId)
-	if err != nil {
-		log.Printf("Failed to query goods detail: %v", err)
-		return nil, errno.ErrQueryFailed
-	}
+// encodeGoodsDetail 将商品详情编码为写入 Redis 的缓存数据，version 为数据库记录的版本号
+func encodeGoodsDetail(goodsDetail *proto.GoodsDetail, version uint16) ([]byte, error) {
+	return cachecodec.Marshal(goodsDetail, int64(version))
+}
 
-	// 2. 检查查询结果是否为空
-	if goodsDetail == nil {
-		log.Printf("Goods detail not found for GoodsId: %d", goodsId)
-		return nil, errno.ErrGoodsDetailNull
+// decodeGoodsDetail 将 Redis 中的缓存数据解码为 GoodsDetail 结构体
+// 不兼容的结构版本写入的数据会返回错误，调用方应当作缓存未命中处理
+func decodeGoodsDetail(data string) (*proto.GoodsDetail, error) {
+	var goodsDetail proto.GoodsDetail
+	if _, err := cachecodec.Unmarshal([]byte(data), &goodsDetail); err != nil {
+		return nil, err
 	}
+	return &goodsDetail, nil
+}
 
-	// 3. 检查商品详情数据是否有效
-	if goodsDetail.GoodsId == 0 || goodsDetail.Title == "" || goodsDetail.Price == 0 {
-		log.Printf("Invalid goods detail data: %+v", goodsDetail)
-		return nil, errno.ErrGoodsDetailNull
+// discardCacheEntry 删除无法解码的 Redis 缓存，之后的请求重新回源写入新格式的数据
+func discardCacheEntry(ctx context.Context, cacheKey string, reason error) {
+	logger.FromContext(ctx).Warn("Discard cache entry", zap.String("key", cacheKey), zap.NamedError("reason", reason))
+	if err := redis.GetClient().Del(ctx, cacheKey).Err(); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete cache entry", zap.String("key", cacheKey), zap.Error(err))
 	}
+}
 
-	// 4. 构造返回的响应数据
+// toGoodsDetailProto 将数据库中的商品转换为商品详情响应
+func toGoodsDetailProto(goodsDetail *model.Goods) *proto.GoodsDetail {
+	goodsId := goodsDetail.GoodsId
 	resp := &proto.GoodsDetail{
 		GoodsId:    goodsDetail.GoodsId,
 		CategoryId: goodsDetail.CategoryId,
-		Status:     int32(goodsDetail.Status),
+		Status:     proto.GoodsStatus(goodsDetail.Status),
 		Title:      goodsDetail.Title,
 		Code:       goodsDetail.Code,      // 商品编码
 		BrandName:  goodsDetail.BrandName, // 商品品牌名称
 		Brief:      goodsDetail.Brief,
+		Version:    int32(goodsDetail.Version), // 商品版本号，用于乐观锁更新
 	}
 
-	// 5. 格式化市场价格和价格字段
+	// 格式化市场价格和价格字段
 	if goodsDetail.MarketPrice > 0 {
 		// 如果市场价格大于 0，将其除以 100 转换为浮点数，并格式化为两位小数
 		resp.MarketPrice = fmt.Sprintf("%.2f", float64(goodsDetail.MarketPrice)/100)
 	} else {
 		resp.MarketPrice = "0.00"
-		log.Printf("MarketPrice is zero or invalid for GoodsId: %d", goodsId)
+		zap.L().Warn("MarketPrice is zero or invalid", zap.Int64("goods_id", goodsId))
 	}
 
 	if goodsDetail.Price > 0 {
@@ -160,64 +270,79 @@ func GetGoodsDetailById(ctx context.Context, goodsId int64) (*proto.GoodsDetail,
 		resp.Price = fmt.Sprintf("%.2f", float64(goodsDetail.Price)/100)
 	} else {
 		resp.Price = "0.00"
-		log.Printf("Price is zero or invalid for GoodsId: %d", goodsId)
+		zap.L().Warn("Price is zero or invalid", zap.Int64("goods_id", goodsId))
 	}
+	return resp
+}
 
-	// 6. 将查询结果序列化为 JSON 数据
-	cachedBytes, err := json.Marshal(resp)
+// setGoodsDetailCache 将商品详情写入 Redis 缓存和本地缓存
+// 使用版本号一致性策略时，只有比缓存中更新的版本才会写入 Redis
+func setGoodsDetailCache(ctx context.Context, cacheKey string, resp *proto.GoodsDetail, version uint16) error {
+	// 1. 将查询结果编码为带版本信息的缓存数据
+	cachedBytes, err := encodeGoodsDetail(resp, version)
 	if err != nil {
-		log.Printf("Failed to marshal data: %v", err)
-		return nil, errno.ErrQueryFailed
+		logger.FromContext(ctx).Error("Failed to marshal data", zap.String("key", cacheKey), zap.Error(err))
+		return err
 	}
 
-	// 7. 将序列化后的数据写入 Redis 缓存
+	// 2. 将序列化后的数据写入 Redis 缓存
 	// 设置缓存的基础过期时间（10 分钟）和随机过期时间（0-5 分钟），避免缓存同时过期,解决缓存雪崩
 	baseTTL := 10 * time.Minute
 	randomTTL := time.Duration(rand.Intn(5*60)) * time.Second
 	totalTTL := baseTTL + randomTTL
-	_, err = redis.GetClient().Set(ctx, cacheKey, cachedBytes, totalTTL).Result()
-	if err != nil {
-		log.Printf("Failed to set data in cache: %v", err)
+	if writeStrategy() == StrategyVersioned {
+		ok, err := setIfNewerVersion(ctx, cacheKey, cachedBytes, version, totalTTL)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to set data in cache", zap.String("key", cacheKey), zap.Error(err))
+		} else if !ok {
+			// 缓存中已经是更新的版本，当前数据已过期，不再写入本地缓存
+			logger.FromContext(ctx).Debug("Skip stale cache write", zap.String("key", cacheKey), zap.Uint16("version", version))
+			return nil
+		}
+	} else {
+		_, err = redis.GetClient().Set(ctx, cacheKey, cachedBytes, totalTTL).Result()
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to set data in cache", zap.String("key", cacheKey), zap.Error(err))
+		}
 	}
 
-	//将数据存入本地缓存
-	setLocalCache(cacheKey, resp, time.Minute*10)
-
-	// 返回商品详情响应
-	log.Printf("Returning goods detail response: %+v", resp)
-	return resp, nil
+	// 3. 将数据存入本地缓存，并保存降级使用的旧数据副本
+	setLocalCache(cacheKey, resp, localCacheTTL())
+	setStaleRedis(ctx, cacheKey, cachedBytes)
+	return nil
 }
 
-// UpdateGoodsDetail 更新商品详情，并删除缓存
-func UpdateGoodsDetail(ctx context.Context, goodsId int64, newPrice int64) (*proto.Response, error) {
-	// 1. 更新数据库
-	err := mysql.UpdateGoodsDetail(ctx, goodsId, newPrice)
-	if err != nil {
-		log.Printf("Failed to update goods detail: %v", err)
-		return nil, errno.ErrUpdateFailed
+// InvalidateGoodsCache 删除商品在 Redis 和本地的缓存（包括空值缓存）
+// 商品被更新或新创建时调用
+func InvalidateGoodsCache(ctx context.Context, goodsId int64) error {
+	cacheKey := goodsDetailCacheKey(goodsId)
+	localCache.Delete(cacheKey)
+	if err := redis.GetClient().Del(ctx, cacheKey).Err(); err != nil {
+		return err
+	}
+	// 通知其他实例删除本地缓存
+	if err := cachebus.Publish(ctx, cacheKey); err != nil {
+		logger.FromContext(ctx).Error("Failed to publish cache invalidation", zap.String("key", cacheKey), zap.Error(err))
 	}
+	return nil
+}
 
-	// 2. 删除缓存
-	cacheKey := fmt.Sprintf("goods_detail_%d", goodsId)
-	_, err = redis.GetClient().Del(ctx, cacheKey).Result()
+// setTombstone 为已确认不存在的商品写入短期的空值缓存
+func setTombstone(ctx context.Context, cacheKey string) {
+	ttl := tombstoneTTL()
+	err := redis.GetClient().Set(ctx, cacheKey, tombstoneValue, ttl).Err()
 	if err != nil {
-		log.Printf("Failed to delete cache: %v", err)
-		return nil, errno.ErrCacheDeleteFailed
+		logger.FromContext(ctx).Error("Failed to set tombstone in cache", zap.String("key", cacheKey), zap.Error(err))
 	}
-
-	log.Printf("Cache deleted for GoodsId: %d", goodsId)
-	return &proto.Response{}, nil
+	setLocalCache(cacheKey, tombstoneValue, ttl)
+	// 商品已不存在，旧数据副本也不再返回
+	deleteStaleCopy(ctx, cacheKey)
 }
 
-
-//设置本地缓存
-func setLocalCache(key string,value interface{},ttl time.Duration){
-	//设置本地缓存
-	localCache.Store(key,value)
-
-	// 启动一个 goroutine 来处理缓存过期
-	go func() {
-		time.Sleep(ttl)
-		localCache.Delete(key)
-	}()
-}
\ No newline at end of file
+// tombstoneTTL 返回配置的空值缓存过期时间
+func tombstoneTTL() time.Duration {
+	if cfg := config.Conf.CacheConfig; cfg != nil && cfg.TombstoneTTL > 0 {
+		return cfg.TombstoneTTL
+	}
+	return defaultTombstoneTTL
+}
@@ -0,0 +1,155 @@
+package goods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goods_srv/config"
+	"goods_srv/errno"
+	"goods_srv/hotkey"
+	"goods_srv/logger"
+	"goods_srv/proto"
+	"time"
+
+	"go.uber.org/zap"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// 热点商品探测
+// 直播间主播讲解某个商品时，大量观众会同时请求同一商品详情。
+// 使用滑动窗口统计每个商品的请求数，达到阈值的热点商品固定在本地缓存中不被淘汰，
+// 并在本地缓存过期前由后台提前刷新，避免热点数据过期瞬间的并发回源。
+
+const (
+	// hotKeySweepInterval 重新统计热点 key 并刷新即将过期数据的间隔
+	hotKeySweepInterval = time.Second
+
+	// 未配置时的默认值
+	defaultHotKeyWindow       = 10 * time.Second
+	defaultHotKeyThreshold    = 1000
+	defaultHotKeyMaxKeys      = 100
+	defaultHotKeyRefreshAhead = time.Minute
+)
+
+var (
+	// hotKeys 热点 key 探测器，InitHotKey 之前使用默认配置
+	hotKeys = hotkey.New(hotkey.Options{
+		Window:    defaultHotKeyWindow,
+		Threshold: defaultHotKeyThreshold,
+		MaxKeys:   defaultHotKeyMaxKeys,
+	})
+	// refreshAhead 本地缓存剩余有效期小于该值时提前刷新
+	refreshAhead = defaultHotKeyRefreshAhead
+)
+
+// InitHotKey 根据配置创建热点 key 探测器，并启动后台刷新
+func InitHotKey(ctx context.Context, cfg *config.HotKeyConfig) {
+	opts := hotkey.Options{
+		Window:    defaultHotKeyWindow,
+		Threshold: defaultHotKeyThreshold,
+		MaxKeys:   defaultHotKeyMaxKeys,
+	}
+	if cfg != nil {
+		if cfg.Window > 0 {
+			opts.Window = cfg.Window
+		}
+		if cfg.Buckets > 0 {
+			opts.Buckets = cfg.Buckets
+		}
+		if cfg.Threshold > 0 {
+			opts.Threshold = cfg.Threshold
+		}
+		if cfg.MaxKeys > 0 {
+			opts.MaxKeys = cfg.MaxKeys
+		}
+		if cfg.RefreshAhead > 0 {
+			refreshAhead = cfg.RefreshAhead
+		}
+	}
+	hotKeys = hotkey.New(opts)
+	go hotKeyLoop(ctx)
+}
+
+// GetHotKeys 返回当前实例探测到的热点 key
+func GetHotKeys(ctx context.Context) (*proto.HotKeysResp, error) {
+	list := hotKeys.HotKeys()
+	data := make([]*proto.HotKey, 0, len(list))
+	for _, hk := range list {
+		data = append(data, &proto.HotKey{
+			Key:     hk.Key,
+			GoodsId: goodsIdFromCacheKey(hk.Key),
+			Count:   int64(hk.Count),
+			Pinned:  localCache.Pinned(hk.Key),
+		})
+	}
+	return &proto.HotKeysResp{Data: data}, nil
+}
+
+// pinLocalCache 将热点商品详情固定在本地缓存中
+func pinLocalCache(key string, value *proto.GoodsDetail) {
+	size := int64(len(key) + gproto.Size(value))
+	localCache.SetPinned(key, value, size, localCacheTTL())
+}
+
+// hotKeyLoop 定期降级不再热的 key，并刷新即将过期的热点数据
+func hotKeyLoop(ctx context.Context) {
+	ticker := time.NewTicker(hotKeySweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, key := range hotKeys.Sweep() {
+				logger.FromContext(ctx).Info("Hot key cooled down", zap.String("key", key))
+				localCache.Unpin(key)
+			}
+			for _, hk := range hotKeys.HotKeys() {
+				// 已确认不存在的商品不固定也不刷新，空值缓存过期后再重新检查
+				if v, ok := localCache.Get(hk.Key); ok && v == tombstoneValue {
+					localCache.Unpin(hk.Key)
+					continue
+				}
+				// 已在本地缓存中的热点 key 立即固定，不用等到即将过期时刷新
+				localCache.Pin(hk.Key)
+				ttl, ok := localCache.TTL(hk.Key)
+				if ok && ttl > refreshAhead {
+					continue
+				}
+				refreshHotKey(ctx, hk.Key)
+			}
+		}
+	}
+}
+
+// refreshHotKey 重新加载热点商品详情并固定在本地缓存中
+func refreshHotKey(ctx context.Context, cacheKey string) {
+	goodsId := goodsIdFromCacheKey(cacheKey)
+	if goodsId == 0 {
+		return
+	}
+	goodsDetail, hit, err := getGoodsDetailFromRedis(ctx, cacheKey)
+	if !hit {
+		goodsDetail, err = loadGoodsDetail(ctx, goodsId, cacheKey)
+	}
+	if errors.Is(err, errno.ErrGoodsDetailNull) {
+		// 商品已删除或不存在，本地缓存中已写入空值缓存，取消固定
+		logger.FromContext(ctx).Debug("Hot key not found, skip refresh", zap.String("key", cacheKey))
+		localCache.Unpin(cacheKey)
+		return
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to refresh hot key", zap.String("key", cacheKey), zap.Error(err))
+		return
+	}
+	pinLocalCache(cacheKey, goodsDetail)
+}
+
+// goodsIdFromCacheKey 从商品详情缓存 key 中解析商品 ID
+func goodsIdFromCacheKey(cacheKey string) int64 {
+	var goodsId int64
+	if _, err := fmt.Sscanf(cacheKey, "goods_detail_%d", &goodsId); err != nil {
+		return 0
+	}
+	return goodsId
+}
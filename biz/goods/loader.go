@@ -0,0 +1,108 @@
+package goods
+
+import (
+	"context"
+	"fmt"
+	"goods_srv/dao/mysql"
+	"goods_srv/dao/redis"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/metrics"
+	"goods_srv/proto"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// 缓存未命中时的回源逻辑
+// 1. 进程内使用 singleflight 合并同一商品的并发请求，只有一个请求去竞争分布式锁
+// 2. 拿到分布式锁后再查一次 Redis，其他实例可能已经回源并写入了缓存
+// 这样热点商品的一波并发请求在整个集群内只会查询一次数据库
+
+var (
+	detailGroup singleflight.Group
+
+	loadResultTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "goods_detail",
+		Name:      "load_total",
+		Help:      "缓存未命中后的回源结果：coalesced 被合并的请求，recheck_hit 加锁后命中 Redis，db 查询数据库",
+	}, []string{"result"})
+)
+
+func init() {
+	metrics.MustRegister(loadResultTotal)
+}
+
+// loadGoodsDetail 缓存未命中时回源加载商品详情，同一商品的并发请求只会执行一次
+func loadGoodsDetail(ctx context.Context, goodsId int64, cacheKey string) (*proto.GoodsDetail, error) {
+	executed := false
+	// 使用不会被取消的 context，避免发起回源的请求被取消时其他合并的请求一起失败
+	v, err, _ := detailGroup.Do(cacheKey, func() (interface{}, error) {
+		executed = true
+		return loadGoodsDetailWithLock(context.WithoutCancel(ctx), goodsId, cacheKey)
+	})
+	if !executed {
+		loadResultTotal.WithLabelValues("coalesced").Inc()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.(*proto.GoodsDetail), nil
+}
+
+// loadGoodsDetailWithLock 持有分布式锁查询数据库并写入缓存
+func loadGoodsDetailWithLock(ctx context.Context, goodsId int64, cacheKey string) (*proto.GoodsDetail, error) {
+	// 构造分布式锁的 key。
+	mutexname := fmt.Sprintf("lock_goods_detail_%d", goodsId)
+
+	// 创建 Redis 分布式锁。
+	mutex := redis.Rs.NewMutex(mutexname)
+
+	// 尝试获取锁。
+	if err := mutex.LockContext(ctx); err != nil {
+		return nil, errno.ErrGetLockFailed
+	}
+	defer mutex.UnlockContext(ctx) // 确保在函数结束时释放锁。
+
+	// 1. 拿到锁后再查一次 Redis，等锁期间其他实例可能已经写入了缓存
+	if goodsDetail, hit, err := getGoodsDetailFromRedis(ctx, cacheKey); hit {
+		loadResultTotal.WithLabelValues("recheck_hit").Inc()
+		if err == nil {
+			setLocalCache(cacheKey, goodsDetail, localCacheTTL())
+		}
+		return goodsDetail, err
+	}
+
+	// 2. 使用商品 ID 从 MySQL 数据库中查询商品详情
+	loadResultTotal.WithLabelValues("db").Inc()
+	goodsDetail, err := mysql.GetGoodsDetailById(ctx, goodsId)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to query goods detail", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, errno.ErrQueryFailed
+	}
+
+	// 3. 检查查询结果是否为空
+	if goodsDetail == nil {
+		logger.FromContext(ctx).Info("Goods detail not found", zap.Int64("goods_id", goodsId))
+		// 缓存空值，避免已删除的商品反复穿透到数据库
+		setTombstone(ctx, cacheKey)
+		return nil, errno.ErrGoodsDetailNull
+	}
+
+	// 4. 检查商品详情数据是否有效
+	if goodsDetail.GoodsId == 0 || goodsDetail.Title == "" || goodsDetail.Price == 0 {
+		logger.FromContext(ctx).Warn("Invalid goods detail data", zap.Any("goods", goodsDetail))
+		return nil, errno.ErrGoodsDetailNull
+	}
+
+	// 5. 构造返回的响应数据
+	resp := toGoodsDetailProto(goodsDetail)
+
+	// 6. 将查询结果写入 Redis 缓存和本地缓存
+	if err := setGoodsDetailCache(ctx, cacheKey, resp, goodsDetail.Version); err != nil {
+		return nil, errno.ErrQueryFailed
+	}
+	return resp, nil
+}
@@ -0,0 +1,100 @@
+package goods
+
+import (
+	"goods_srv/config"
+	"goods_srv/localcache"
+	"goods_srv/metrics"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// defaultLocalCacheTTL 未配置时商品详情本地缓存的默认过期时间
+const defaultLocalCacheTTL = 10 * time.Minute
+
+// localCache 商品详情的本地缓存，InitLocalCache 之前使用默认容量
+var localCache = localcache.New(localcache.Options{})
+
+// InitLocalCache 根据配置创建本地缓存，并注册命中率等监控指标
+func InitLocalCache(cfg *config.LocalCacheConfig) {
+	if cfg != nil {
+		localCache = localcache.New(localcache.Options{
+			MaxEntries: cfg.MaxEntries,
+			MaxBytes:   cfg.MaxBytes,
+			Policy:     cfg.Policy,
+		})
+		staleCache = localcache.New(localcache.Options{
+			MaxEntries: cfg.MaxEntries,
+			MaxBytes:   cfg.MaxBytes,
+			Policy:     cfg.Policy,
+		})
+	}
+	registerLocalCacheMetrics()
+}
+
+// setLocalCache 设置本地缓存，按序列化后的大小估算条目占用的字节数
+func setLocalCache(key string, value interface{}, ttl time.Duration) {
+	size := int64(len(key))
+	switch v := value.(type) {
+	case gproto.Message:
+		size += int64(gproto.Size(v))
+	case string:
+		size += int64(len(v))
+	case *roomBinding:
+		size += int64(8 * (len(v.GoodsIds) + 1))
+	}
+	localCache.Set(key, value, size, ttl)
+	// 同时保存一份旧数据副本，存储故障时降级使用
+	if value != tombstoneValue {
+		setStaleLocal(key, value, size)
+	}
+}
+
+// EvictLocalCache 删除本地缓存，收到其他实例的失效消息时调用
+func EvictLocalCache(keys []string) {
+	for _, key := range keys {
+		localCache.Delete(key)
+	}
+}
+
+// ResetLocalCache 清空本地缓存，失效消息订阅断线重连后调用
+func ResetLocalCache() {
+	localCache.Clear()
+}
+
+// localCacheTTL 返回配置的本地缓存过期时间
+func localCacheTTL() time.Duration {
+	if cfg := config.Conf.LocalCacheConfig; cfg != nil && cfg.TTL > 0 {
+		return cfg.TTL
+	}
+	return defaultLocalCacheTTL
+}
+
+// registerLocalCacheMetrics 将本地缓存的统计信息注册为监控指标
+func registerLocalCacheMetrics() {
+	counter := func(name, help string, fn func(s localcache.Stats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "local_cache",
+			Name:      name,
+			Help:      help,
+		}, func() float64 { return float64(fn(localCache.Stats())) })
+	}
+	gauge := func(name, help string, fn func(s localcache.Stats) float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "local_cache",
+			Name:      name,
+			Help:      help,
+		}, func() float64 { return fn(localCache.Stats()) })
+	}
+	metrics.MustRegister(
+		counter("hits_total", "本地缓存命中次数", func(s localcache.Stats) uint64 { return s.Hits }),
+		counter("misses_total", "本地缓存未命中次数", func(s localcache.Stats) uint64 { return s.Misses }),
+		counter("evictions_total", "本地缓存因容量不足淘汰的条目数", func(s localcache.Stats) uint64 { return s.Evictions }),
+		counter("expirations_total", "本地缓存因过期删除的条目数", func(s localcache.Stats) uint64 { return s.Expirations }),
+		gauge("entries", "本地缓存当前条目数", func(s localcache.Stats) float64 { return float64(s.Entries) }),
+		gauge("bytes", "本地缓存当前占用字节数", func(s localcache.Stats) float64 { return float64(s.Bytes) }),
+	)
+}
@@ -0,0 +1,163 @@
+package goods
+
+import (
+	"context"
+	"goods_srv/audit"
+	"goods_srv/bloomfilter"
+	"goods_srv/cachebus"
+	"goods_srv/dao/mysql"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/model"
+	"goods_srv/proto"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 商品管理：新增、删除、修改状态和分页查询商品，所有修改都会记录操作人和修改日志
+
+// defaultPageSize 商品列表未指定每页条数时的默认值
+const defaultPageSize = 20
+
+// CreateGoods 新增商品，并加入布隆过滤器、清理之前查询留下的空值缓存
+func CreateGoods(ctx context.Context, req *proto.CreateGoodsReq) (*proto.GoodsDetail, error) {
+	goods := &model.Goods{
+		GoodsId:     req.GetGoodsId(),
+		CategoryId:  req.GetCategoryId(),
+		BrandName:   req.GetBrandName(),
+		Code:        req.GetCode(),
+		Status:      int8(req.GetStatus()),
+		Title:       req.GetTitle(),
+		MarketPrice: req.GetMarketPrice(),
+		Price:       req.GetPrice(),
+		Brief:       req.GetBrief(),
+	}
+	if err := mysql.CreateGoods(ctx, goods, changeInfo(ctx, "")); err != nil {
+		return nil, err
+	}
+
+	// 商品入库后立即加入布隆过滤器，并通知其他实例加入，不用等后台增量同步
+	bloomfilter.Add(ctx, goods.GoodsId)
+	if err := cachebus.PublishNewGoods(ctx, goods.GoodsId); err != nil {
+		logger.FromContext(ctx).Error("Failed to publish new goods", zap.Int64("goods_id", goods.GoodsId), zap.Error(err))
+	}
+	if err := InvalidateGoodsCache(ctx, goods.GoodsId); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete cache for new goods", zap.Int64("goods_id", goods.GoodsId), zap.Error(err))
+	}
+	logger.FromContext(ctx).Info("Goods created", zap.Int64("goods_id", goods.GoodsId))
+	return toGoodsDetailProto(goods), nil
+}
+
+// DeleteGoods 软删除商品，并将缓存替换为空值缓存
+// 布隆过滤器不支持删除，已删除的商品由空值缓存拦截，下次重建时自然移除
+func DeleteGoods(ctx context.Context, goodsId int64, reason string) error {
+	if err := mysql.DeleteGoods(ctx, goodsId, changeInfo(ctx, reason)); err != nil {
+		return err
+	}
+	evictDeletedGoods(ctx, goodsId)
+	logger.FromContext(ctx).Info("Goods deleted", zap.Int64("goods_id", goodsId))
+	return nil
+}
+
+// ChangeGoodsStatus 按允许的状态流转修改商品状态，返回修改后的商品详情
+// 商品变为可售或不再可售时，通知订阅了直播间的客户端
+func ChangeGoodsStatus(ctx context.Context, goodsId int64, status proto.GoodsStatus, reason string) (*proto.GoodsDetail, error) {
+	goods, from, err := mysql.ChangeGoodsStatus(ctx, goodsId, int8(status), changeInfo(ctx, reason))
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Goods status changed",
+		zap.Int64("goods_id", goodsId),
+		zap.Stringer("from", proto.GoodsStatus(from)),
+		zap.Stringer("to", proto.GoodsStatus(goods.Status)),
+	)
+
+	if goods.Status == model.GoodsStatusDeleted {
+		evictDeletedGoods(ctx, goodsId)
+		return toGoodsDetailProto(goods), nil
+	}
+	if err := syncGoodsCacheAfterWrite(ctx, goodsId); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete cache", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, errno.ErrCacheDeleteFailed
+	}
+	switch {
+	case from != model.GoodsStatusOnShelf && goods.Status == model.GoodsStatusOnShelf:
+		publishGoodsEvent(ctx, goodsId, proto.RoomGoodsEventType_EVENT_GOODS_ADDED, "")
+	case from == model.GoodsStatusOnShelf && goods.Status != model.GoodsStatusOnShelf:
+		publishGoodsEvent(ctx, goodsId, proto.RoomGoodsEventType_EVENT_GOODS_REMOVED, "")
+	}
+	return toGoodsDetailProto(goods), nil
+}
+
+// evictDeletedGoods 将已删除商品的缓存替换为空值缓存，并从绑定了它的直播间中下架
+func evictDeletedGoods(ctx context.Context, goodsId int64) {
+	cacheKey := goodsDetailCacheKey(goodsId)
+	setTombstone(ctx, cacheKey)
+	// 通知其他实例删除本地缓存
+	if err := cachebus.Publish(ctx, cacheKey); err != nil {
+		logger.FromContext(ctx).Error("Failed to publish cache invalidation", zap.Error(err))
+	}
+	publishGoodsEvent(ctx, goodsId, proto.RoomGoodsEventType_EVENT_GOODS_REMOVED, "")
+}
+
+// ListGoods 按条件分页查询商品，管理后台使用，直接查询数据库
+func ListGoods(ctx context.Context, req *proto.ListGoodsReq) (*proto.ListGoodsResp, error) {
+	filter := &mysql.GoodsFilter{
+		CategoryId: req.GetCategoryId(),
+		BrandName:  req.GetBrandName(),
+		Keyword:    req.GetKeyword(),
+		Page:       max(int(req.GetPage()), 1),
+		PageSize:   int(req.GetPageSize()),
+	}
+	if req.Status != nil {
+		status := int8(req.GetStatus())
+		filter.Status = &status
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	}
+
+	goodsList, total, err := mysql.ListGoods(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*proto.GoodsDetail, 0, len(goodsList))
+	for _, goods := range goodsList {
+		data = append(data, toGoodsDetailProto(goods))
+	}
+	return &proto.ListGoodsResp{Total: total, Data: data}, nil
+}
+
+// GetGoodsHistory 分页查询商品的修改日志
+func GetGoodsHistory(ctx context.Context, goodsId int64, page, pageSize int) (*proto.GoodsHistoryResp, error) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	logs, total, err := mysql.GetGoodsChangeLogs(ctx, goodsId, max(page, 1), pageSize)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*proto.GoodsChange, 0, len(logs))
+	for _, changeLog := range logs {
+		data = append(data, &proto.GoodsChange{
+			Id:       int64(changeLog.ID),
+			GoodsId:  changeLog.GoodsId,
+			Action:   changeLog.Action,
+			OldValue: changeLog.OldValue,
+			NewValue: changeLog.NewValue,
+			Operator: changeLog.Operator,
+			Reason:   changeLog.Reason,
+			CreateAt: changeLog.CreateAt.Format(time.DateTime),
+		})
+	}
+	return &proto.GoodsHistoryResp{Total: total, Data: data}, nil
+}
+
+// changeInfo 根据请求中的操作人和修改原因构造审计信息
+func changeInfo(ctx context.Context, reason string) mysql.ChangeInfo {
+	return mysql.ChangeInfo{
+		Operator: audit.Operator(ctx),
+		Reason:   reason,
+	}
+}
@@ -0,0 +1,173 @@
+package goods
+
+import (
+	"context"
+	"goods_srv/bloomfilter"
+	"goods_srv/dao/mysql"
+	"goods_srv/dao/redis"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/proto"
+
+	"go.uber.org/zap"
+)
+
+// goodsDetails 批量查询商品详情的结果，每个商品要么查询到，要么确认不存在，要么暂时无法查询
+type goodsDetails struct {
+	found       map[int64]*proto.GoodsDetail // 查询到的商品详情
+	unavailable map[int64]bool               // 数据库查询失败且没有旧数据的商品，不能当作不存在
+	stale       bool                         // 是否包含降级返回的旧数据
+}
+
+// getGoodsDetails 批量获取商品详情，依次查询本地缓存、Redis（一次 MGET）和数据库（一次 IN 查询）
+// 不在 found 和 unavailable 中的商品确认不存在
+// 数据库查询失败时降级返回旧数据，没有旧数据的商品标记为 unavailable
+func getGoodsDetails(ctx context.Context, goodsIds []int64) (*goodsDetails, error) {
+	result := make(map[int64]*proto.GoodsDetail, len(goodsIds))
+
+	// 1. 查询本地缓存
+	redisMissing := make([]int64, 0, len(goodsIds))
+	for _, goodsId := range goodsIds {
+		if _, ok := result[goodsId]; ok {
+			continue
+		}
+		if !bloomfilter.MightContain(ctx, goodsId) {
+			continue
+		}
+		if v, ok := localCache.Get(goodsDetailCacheKey(goodsId)); ok {
+			if v != tombstoneValue {
+				result[goodsId] = v.(*proto.GoodsDetail)
+			}
+			continue
+		}
+		redisMissing = append(redisMissing, goodsId)
+	}
+	if len(redisMissing) == 0 {
+		return &goodsDetails{found: result}, nil
+	}
+
+	// 2. 本地缓存未命中的商品一次性从 Redis 中批量获取
+	dbMissing := redisMissing
+	keys := make([]string, 0, len(redisMissing))
+	for _, goodsId := range redisMissing {
+		keys = append(keys, goodsDetailCacheKey(goodsId))
+	}
+	values, err := redis.GetClient().MGet(ctx, keys...).Result()
+	if err != nil {
+		// Redis 查询失败时全部从数据库查询
+		logger.FromContext(ctx).Error("Failed to mget data from cache", zap.Error(err))
+	} else {
+		dbMissing = make([]int64, 0, len(redisMissing))
+		for i, value := range values {
+			goodsId, cacheKey := redisMissing[i], keys[i]
+			data, ok := value.(string)
+			if !ok || data == "" {
+				dbMissing = append(dbMissing, goodsId)
+				continue
+			}
+			if data == tombstoneValue {
+				setLocalCache(cacheKey, tombstoneValue, tombstoneTTL())
+				continue
+			}
+			goodsDetail, err := decodeGoodsDetail(data)
+			if err != nil {
+				discardCacheEntry(ctx, cacheKey, err)
+				dbMissing = append(dbMissing, goodsId)
+				continue
+			}
+			setLocalCache(cacheKey, goodsDetail, localCacheTTL())
+			result[goodsId] = goodsDetail
+		}
+	}
+	if len(dbMissing) == 0 {
+		return &goodsDetails{found: result}, nil
+	}
+
+	// 3. Redis 也未命中的商品一次性从数据库中批量查询，并写入缓存
+	goodsList, err := mysql.GetGoodsByIdList(ctx, dbMissing)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to query goods list", zap.Error(err))
+		return getStaleGoodsDetails(ctx, result, dbMissing)
+	}
+	found := make(map[int64]bool, len(goodsList))
+	for _, goods := range goodsList {
+		found[goods.GoodsId] = true
+		// 检查商品详情数据是否有效
+		if goods.Title == "" || goods.Price == 0 {
+			logger.FromContext(ctx).Warn("Invalid goods detail data", zap.Any("goods", goods))
+			continue
+		}
+		resp := toGoodsDetailProto(goods)
+		if err := setGoodsDetailCache(ctx, goodsDetailCacheKey(goods.GoodsId), resp, goods.Version); err != nil {
+			logger.FromContext(ctx).Error("Failed to set goods detail cache", zap.Int64("goods_id", goods.GoodsId), zap.Error(err))
+		}
+		result[goods.GoodsId] = resp
+	}
+
+	// 数据库中也不存在的商品写入空值缓存
+	for _, goodsId := range dbMissing {
+		if !found[goodsId] {
+			setTombstone(ctx, goodsDetailCacheKey(goodsId))
+		}
+	}
+	return &goodsDetails{found: result}, nil
+}
+
+// getStaleGoodsDetails 数据库查询失败时，为未命中缓存的商品降级返回旧数据，并在后台重新回源
+// 没有任何商品可以返回时才返回错误，部分商品没有旧数据时将这些商品标记为 unavailable
+func getStaleGoodsDetails(ctx context.Context, result map[int64]*proto.GoodsDetail, goodsIds []int64) (*goodsDetails, error) {
+	if maxStaleness() <= 0 {
+		return nil, errno.ErrQueryFailed
+	}
+	served := 0
+	unavailable := make(map[int64]bool)
+	for _, goodsId := range goodsIds {
+		cacheKey := goodsDetailCacheKey(goodsId)
+		if goodsDetail, ok := getStaleGoodsDetail(ctx, cacheKey); ok {
+			result[goodsId] = goodsDetail
+			served++
+		} else {
+			unavailable[goodsId] = true
+		}
+		revalidateGoodsDetail(goodsId, cacheKey)
+	}
+	if len(result) == 0 {
+		return nil, errno.ErrQueryFailed
+	}
+	logger.FromContext(ctx).Warn("Serving stale goods details", zap.Int("served", served), zap.Int("total", len(goodsIds)))
+	return &goodsDetails{found: result, unavailable: unavailable, stale: served > 0}, nil
+}
+
+// BatchGetGoodsDetail 批量获取商品详情，结果按请求中的商品顺序返回
+// 不存在的商品标记 NotFound，存储故障且没有旧数据的商品标记 Unavailable
+func BatchGetGoodsDetail(ctx context.Context, goodsIds []int64) (*proto.BatchGoodsDetailResp, error) {
+	// 去重后批量查询，同时统计请求频率用于热点探测
+	seen := make(map[int64]bool, len(goodsIds))
+	idList := make([]int64, 0, len(goodsIds))
+	for _, goodsId := range goodsIds {
+		if seen[goodsId] {
+			continue
+		}
+		seen[goodsId] = true
+		idList = append(idList, goodsId)
+		hotKeys.Record(goodsDetailCacheKey(goodsId))
+	}
+
+	details, err := getGoodsDetails(ctx, idList)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.GoodsDetailResult, 0, len(goodsIds))
+	for _, goodsId := range goodsIds {
+		goodsDetail, ok := details.found[goodsId]
+		unavailable := details.unavailable[goodsId]
+		data = append(data, &proto.GoodsDetailResult{
+			GoodsId:     goodsId,
+			Detail:      goodsDetail,
+			NotFound:    !ok && !unavailable,
+			Unavailable: unavailable,
+		})
+	}
+	return &proto.BatchGoodsDetailResp{Data: data, Stale: details.stale}, nil
+}
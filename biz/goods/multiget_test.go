@@ -0,0 +1,120 @@
+package goods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goods_srv/config"
+	"goods_srv/dao/mysql"
+	"goods_srv/errno"
+	"goods_srv/model"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// breakDatabase 替换为已关闭的数据库连接，模拟数据库故障
+func breakDatabase(t *testing.T) {
+	t.Helper()
+	gdb, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "broken.db")), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+	mysql.InitWithDB(gdb)
+}
+
+// waitRevalidated 等待后台重新回源的 goroutine 全部退出，避免影响之后的测试
+func waitRevalidated(t *testing.T) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		pending := false
+		revalidating.Range(func(_, _ interface{}) bool {
+			pending = true
+			return false
+		})
+		if !pending {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stale cache revalidation still running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestBatchGetGoodsDetailPartialStale 数据库故障时，有旧数据的商品降级返回旧数据，
+// 没有旧数据的商品标记 Unavailable 而不是 NotFound，已确认不存在的商品仍然标记 NotFound
+func TestBatchGetGoodsDetailPartialStale(t *testing.T) {
+	mr, _ := setupConsistencyTest(t, StrategyDelete)
+	// 后台重新回源最多重试到旧数据过期，保留时间较短时测试结束前重试也会结束
+	config.Conf.CacheConfig.MaxStaleness = time.Second
+	t.Cleanup(func() { waitRevalidated(t) })
+	ctx := context.Background()
+
+	const (
+		staleId       int64 = 2001 // 有旧数据
+		unavailableId int64 = 2002 // 没有旧数据
+		notFoundId    int64 = 2003 // 已确认不存在
+	)
+	for _, goodsId := range []int64{staleId, unavailableId} {
+		err := mysql.CreateGoods(ctx, &model.Goods{
+			GoodsId:    goodsId,
+			CategoryId: 1,
+			Code:       fmt.Sprintf("G%d", goodsId),
+			Title:      "测试商品",
+			Price:      10000,
+		}, mysql.ChangeInfo{Operator: "test"})
+		if err != nil {
+			t.Fatalf("create goods: %v", err)
+		}
+	}
+
+	// 加载商品详情时保存旧数据副本，查询不存在的商品写入空值缓存
+	if _, err := GetGoodsDetailById(ctx, staleId); err != nil {
+		t.Fatalf("get goods detail: %v", err)
+	}
+	if _, err := GetGoodsDetailById(ctx, notFoundId); !errors.Is(err, errno.ErrGoodsDetailNull) {
+		t.Fatalf("get missing goods detail: %v, want %v", err, errno.ErrGoodsDetailNull)
+	}
+	// 商品详情缓存过期，只剩旧数据副本
+	cacheKey := goodsDetailCacheKey(staleId)
+	localCache.Delete(cacheKey)
+	mr.Del(cacheKey)
+
+	breakDatabase(t)
+
+	resp, err := BatchGetGoodsDetail(ctx, []int64{staleId, unavailableId, notFoundId})
+	if err != nil {
+		t.Fatalf("batch get goods detail: %v", err)
+	}
+	if !resp.Stale {
+		t.Error("response not marked stale")
+	}
+	if len(resp.Data) != 3 {
+		t.Fatalf("got %d results, want 3", len(resp.Data))
+	}
+	if r := resp.Data[0]; r.Detail == nil || r.Detail.GoodsId != staleId || r.NotFound || r.Unavailable {
+		t.Errorf("stale goods result = %v, want stale detail", r)
+	}
+	if r := resp.Data[1]; r.Detail != nil || r.NotFound || !r.Unavailable {
+		t.Errorf("goods without stale copy result = %v, want unavailable", r)
+	}
+	if r := resp.Data[2]; r.Detail != nil || !r.NotFound || r.Unavailable {
+		t.Errorf("missing goods result = %v, want not found", r)
+	}
+
+	// 所有商品都无法查询时整个请求失败
+	if _, err := BatchGetGoodsDetail(ctx, []int64{unavailableId}); !errors.Is(err, errno.ErrQueryFailed) {
+		t.Errorf("batch get unavailable goods: %v, want %v", err, errno.ErrQueryFailed)
+	}
+}
@@ -0,0 +1,212 @@
+package goods
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goods_srv/audit"
+	"goods_srv/config"
+	"goods_srv/dao/mysql"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/model"
+	"goods_srv/proto"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 定时改价
+// 主播预告"晚上 8 点降价"时，运营提前创建定时改价，由后台调度在生效时间改价、在结束时间恢复原价。
+// 每个实例都会运行调度，通过数据库中定时改价状态的条件更新保证同一条记录只被一个实例处理。
+
+const (
+	// schedulerOperator 定时改价调度修改商品时记录的操作人，只用于展示
+	// 操作人可以由调用方通过元数据指定，修改日志是否来自定时改价由 ScheduleId 判断
+	schedulerOperator = "price_scheduler"
+
+	// 未配置时的默认值
+	defaultPriceScheduleInterval  = 5 * time.Second
+	defaultPriceScheduleBatchSize = 100
+)
+
+// priceScheduleStatusNames 定时改价状态在接口中的名称
+var priceScheduleStatusNames = map[int8]string{
+	model.PriceSchedulePending:  "pending",
+	model.PriceScheduleActive:   "active",
+	model.PriceScheduleFinished: "finished",
+	model.PriceScheduleCanceled: "canceled",
+}
+
+// InitPriceScheduler 根据配置启动定时改价调度
+func InitPriceScheduler(ctx context.Context, cfg *config.PriceScheduleConfig) {
+	interval, batchSize := defaultPriceScheduleInterval, defaultPriceScheduleBatchSize
+	if cfg != nil {
+		if cfg.Interval > 0 {
+			interval = cfg.Interval
+		}
+		if cfg.BatchSize > 0 {
+			batchSize = cfg.BatchSize
+		}
+	}
+	go priceScheduleLoop(ctx, interval, batchSize)
+}
+
+// priceScheduleLoop 定期处理到期的定时改价
+func priceScheduleLoop(ctx context.Context, interval time.Duration, batchSize int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runDuePriceSchedules(ctx, batchSize)
+		}
+	}
+}
+
+// runDuePriceSchedules 应用到达生效时间的定时改价，恢复到达结束时间的定时改价
+func runDuePriceSchedules(ctx context.Context, batchSize int) {
+	schedules, err := mysql.GetDuePriceSchedules(ctx, time.Now(), batchSize)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to query due price schedules", zap.Error(err))
+		return
+	}
+	for _, schedule := range schedules {
+		change := mysql.ChangeInfo{Operator: schedulerOperator}
+		switch schedule.Status {
+		case model.PriceSchedulePending:
+			change.Reason = fmt.Sprintf("定时改价生效 #%d", schedule.ID)
+			err = mysql.ApplyPriceSchedule(ctx, schedule, change)
+			if errors.Is(err, errno.ErrGoodsDetailNotFound) {
+				// 商品已被删除，取消定时改价
+				logger.FromContext(ctx).Info("Goods not found, cancel price schedule", zap.Uint("schedule_id", schedule.ID))
+				err = mysql.CancelPriceSchedule(ctx, schedule.ID)
+			}
+		case model.PriceScheduleActive:
+			change.Reason = fmt.Sprintf("定时改价结束 #%d", schedule.ID)
+			err = mysql.RevertPriceSchedule(ctx, schedule, change)
+		}
+		if errors.Is(err, errno.ErrPriceScheduleHandled) {
+			continue // 其他实例已处理
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to run price schedule", zap.Uint("schedule_id", schedule.ID), zap.Error(err))
+			continue
+		}
+
+		// 价格已修改，按配置的一致性策略删除或更新缓存
+		if err := syncGoodsCacheAfterWrite(ctx, schedule.GoodsId); err != nil {
+			logger.FromContext(ctx).Error("Failed to sync cache", zap.Int64("goods_id", schedule.GoodsId), zap.Error(err))
+		}
+		if goods, err := mysql.GetGoodsDetailById(ctx, schedule.GoodsId); err == nil && goods != nil {
+			publishPriceChanged(ctx, schedule.GoodsId, goods.Price)
+		}
+		logger.FromContext(ctx).Info("Price schedule handled", zap.Uint("schedule_id", schedule.ID), zap.Int64("goods_id", schedule.GoodsId))
+	}
+}
+
+// SchedulePriceChange 创建定时改价，effectiveTo 为零值表示生效后不再恢复原价
+func SchedulePriceChange(ctx context.Context, goodsId, price int64, effectiveFrom, effectiveTo time.Time, reason string) (*proto.PriceChange, error) {
+	schedule := &model.PriceSchedule{
+		GoodsId:       goodsId,
+		Price:         price,
+		EffectiveFrom: effectiveFrom,
+		Operator:      audit.Operator(ctx),
+		Reason:        reason,
+	}
+	if !effectiveTo.IsZero() {
+		schedule.EffectiveTo = &effectiveTo
+	}
+	if err := mysql.CreatePriceSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Price schedule created", zap.Uint("schedule_id", schedule.ID), zap.Int64("goods_id", goodsId))
+	return toPriceChangeProto(schedule), nil
+}
+
+// GetPriceHistory 查询商品的价格时间线，包括手动改价和定时改价
+func GetPriceHistory(ctx context.Context, goodsId int64) (*proto.PriceHistoryResp, error) {
+	goods, err := mysql.GetGoodsDetailById(ctx, goodsId)
+	if err != nil {
+		return nil, err
+	}
+	if goods == nil {
+		return nil, errno.ErrGoodsDetailNotFound
+	}
+
+	// 1. 手动改价，定时改价调度产生的修改日志由定时改价记录表示
+	logs, err := mysql.GetPriceChangeLogs(ctx, goodsId)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*proto.PriceChange, 0, len(logs))
+	for _, changeLog := range logs {
+		if changeLog.ScheduleId != 0 {
+			continue
+		}
+		var oldValues, newValues struct {
+			Price *int64 `json:"price"`
+		}
+		_ = json.Unmarshal([]byte(changeLog.OldValue), &oldValues)
+		if err := json.Unmarshal([]byte(changeLog.NewValue), &newValues); err != nil || newValues.Price == nil {
+			continue
+		}
+		item := &proto.PriceChange{
+			Price:         formatPrice(*newValues.Price),
+			EffectiveFrom: changeLog.CreateAt.Unix(),
+			Status:        "applied",
+			Operator:      changeLog.Operator,
+			Reason:        changeLog.Reason,
+		}
+		if oldValues.Price != nil {
+			item.OldPrice = formatPrice(*oldValues.Price)
+		}
+		data = append(data, item)
+	}
+
+	// 2. 定时改价
+	schedules, err := mysql.GetPriceSchedules(ctx, goodsId)
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		data = append(data, toPriceChangeProto(schedule))
+	}
+
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].EffectiveFrom < data[j].EffectiveFrom
+	})
+	return &proto.PriceHistoryResp{
+		CurrentPrice: formatPrice(goods.Price),
+		Data:         data,
+	}, nil
+}
+
+// toPriceChangeProto 将定时改价转换为价格变化
+func toPriceChangeProto(schedule *model.PriceSchedule) *proto.PriceChange {
+	item := &proto.PriceChange{
+		ScheduleId:    int64(schedule.ID),
+		Price:         formatPrice(schedule.Price),
+		EffectiveFrom: schedule.EffectiveFrom.Unix(),
+		Status:        priceScheduleStatusNames[schedule.Status],
+		Operator:      schedule.Operator,
+		Reason:        schedule.Reason,
+	}
+	if schedule.EffectiveTo != nil {
+		item.EffectiveTo = schedule.EffectiveTo.Unix()
+	}
+	// 生效后才记录了原价
+	if schedule.Status == model.PriceScheduleActive || schedule.Status == model.PriceScheduleFinished {
+		item.OldPrice = formatPrice(schedule.OriginalPrice)
+	}
+	return item
+}
+
+// formatPrice 将以分为单位的价格格式化为以元为单位、保留两位小数的字符串
+func formatPrice(price int64) string {
+	return fmt.Sprintf("%.2f", float64(price)/100)
+}
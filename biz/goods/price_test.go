@@ -0,0 +1,73 @@
+package goods
+
+import (
+	"context"
+	"goods_srv/audit"
+	"goods_srv/dao/mysql"
+	"goods_srv/model"
+	"testing"
+	"time"
+)
+
+// TestPriceHistoryScheduleSource 修改日志是否来自定时改价由 ScheduleId 判断，
+// 手动改价即使操作人与定时改价调度相同也仍然出现在价格时间线中
+func TestPriceHistoryScheduleSource(t *testing.T) {
+	_, gdb := setupConsistencyTest(t, StrategyDelete)
+	if err := gdb.AutoMigrate(&model.PriceSchedule{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	// 调用方在元数据中伪造定时改价调度的操作人
+	ctx := audit.WithOperator(context.Background(), schedulerOperator)
+	if _, err := UpdateGoodsDetail(ctx, testGoodsId, 15000, nil, "manual"); err != nil {
+		t.Fatalf("update goods: %v", err)
+	}
+
+	schedule := &model.PriceSchedule{
+		GoodsId:       testGoodsId,
+		Price:         20000,
+		EffectiveFrom: time.Now().Add(-time.Second),
+		Operator:      "test",
+	}
+	if err := mysql.CreatePriceSchedule(ctx, schedule); err != nil {
+		t.Fatalf("create price schedule: %v", err)
+	}
+	runDuePriceSchedules(context.Background(), 10)
+
+	logs, err := mysql.GetPriceChangeLogs(ctx, testGoodsId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scheduled int
+	for _, changeLog := range logs {
+		if changeLog.ScheduleId == schedule.ID {
+			scheduled++
+		}
+	}
+	if scheduled != 1 {
+		t.Errorf("%d change logs with schedule id %d, want 1", scheduled, schedule.ID)
+	}
+
+	resp, err := GetPriceHistory(ctx, testGoodsId)
+	if err != nil {
+		t.Fatalf("get price history: %v", err)
+	}
+	var manual, fromSchedule int
+	for _, item := range resp.Data {
+		switch {
+		case item.ScheduleId == int64(schedule.ID):
+			fromSchedule++
+		case item.Operator == schedulerOperator && item.Reason == "manual":
+			manual++
+		}
+	}
+	if manual != 1 {
+		t.Errorf("manual price change listed %d times, want 1: %v", manual, resp.Data)
+	}
+	if fromSchedule != 1 {
+		t.Errorf("scheduled price change listed %d times, want 1: %v", fromSchedule, resp.Data)
+	}
+	if resp.CurrentPrice != "200.00" {
+		t.Errorf("current price = %s, want 200.00", resp.CurrentPrice)
+	}
+}
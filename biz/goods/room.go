@@ -0,0 +1,115 @@
+package goods
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"goods_srv/cachebus"
+	"goods_srv/dao/mysql"
+	"goods_srv/dao/redis"
+	"goods_srv/logger"
+	"math/rand"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// 直播间商品列表缓存
+// 只缓存直播间绑定的商品 ID（按权重排序）和当前讲解的商品 ID，商品详情从商品详情缓存中组装，
+// 因此商品更新只需删除商品详情缓存，绑定关系变化时删除直播间缓存。
+
+// roomBinding 直播间与商品的绑定关系
+type roomBinding struct {
+	CurrentGoodsId int64   `json:"current_goods_id"` // 当前正在讲解的商品 ID
+	GoodsIds       []int64 `json:"goods_ids"`        // 按权重排序的商品 ID
+}
+
+var roomGroup singleflight.Group
+
+// roomGoodsCacheKey 直播间商品列表的缓存 key
+func roomGoodsCacheKey(roomId int64) string {
+	return fmt.Sprintf("room_goods_%d", roomId)
+}
+
+// getRoomBinding 查询直播间绑定的商品，依次查询本地缓存、Redis 和数据库
+// 数据库查询失败时降级返回旧数据，第二个返回值表示返回的是否为旧数据
+func getRoomBinding(ctx context.Context, roomId int64) (*roomBinding, bool, error) {
+	cacheKey := roomGoodsCacheKey(roomId)
+
+	// 1. 查询本地缓存
+	if v, ok := localCache.Get(cacheKey); ok {
+		return v.(*roomBinding), false, nil
+	}
+
+	// 2. 查询 Redis 缓存
+	cachedData, err := redis.GetClient().Get(ctx, cacheKey).Bytes()
+	if err == nil {
+		var binding roomBinding
+		if err := json.Unmarshal(cachedData, &binding); err == nil {
+			setLocalCache(cacheKey, &binding, localCacheTTL())
+			return &binding, false, nil
+		}
+		logger.FromContext(ctx).Error("Failed to unmarshal cached room binding", zap.Int64("room_id", roomId), zap.Error(err))
+	}
+
+	// 3. 从数据库查询，同一直播间的并发请求合并为一次查询
+	v, err, _ := roomGroup.Do(cacheKey, func() (interface{}, error) {
+		return loadRoomBinding(context.WithoutCancel(ctx), roomId, cacheKey)
+	})
+	if err != nil {
+		// 存储故障时降级返回旧数据，并在后台重新回源
+		if canServeStale(err) {
+			if binding, ok := getStaleRoomBinding(ctx, cacheKey); ok {
+				logger.FromContext(ctx).Warn("Serving stale room binding", zap.Int64("room_id", roomId), zap.Error(err))
+				revalidateRoomBinding(roomId, cacheKey)
+				return binding, true, nil
+			}
+		}
+		return nil, false, err
+	}
+	return v.(*roomBinding), false, nil
+}
+
+// loadRoomBinding 从 xx_room_goods 表查询直播间绑定的商品并写入缓存
+func loadRoomBinding(ctx context.Context, roomId int64, cacheKey string) (*roomBinding, error) {
+	objList, err := mysql.GetGoodsByRoomId(ctx, roomId)
+	if err != nil {
+		return nil, err
+	}
+
+	binding := &roomBinding{GoodsIds: make([]int64, 0, len(objList))}
+	for _, obj := range objList {
+		binding.GoodsIds = append(binding.GoodsIds, obj.GoodsId)
+		if obj.IsCurrent == 1 {
+			binding.CurrentGoodsId = obj.GoodsId
+		}
+	}
+
+	data, err := json.Marshal(binding)
+	if err != nil {
+		return nil, err
+	}
+	// 设置缓存的基础过期时间（10 分钟）和随机过期时间（0-5 分钟），避免缓存同时过期
+	totalTTL := 10*time.Minute + time.Duration(rand.Intn(5*60))*time.Second
+	if err := redis.GetClient().Set(ctx, cacheKey, data, totalTTL).Err(); err != nil {
+		logger.FromContext(ctx).Error("Failed to set room binding in cache", zap.Int64("room_id", roomId), zap.Error(err))
+	}
+	setLocalCache(cacheKey, binding, localCacheTTL())
+	setStaleRedis(ctx, cacheKey, data)
+	return binding, nil
+}
+
+// InvalidateRoomCache 删除直播间商品列表缓存，直播间绑定的商品变化时调用
+func InvalidateRoomCache(ctx context.Context, roomId int64) error {
+	cacheKey := roomGoodsCacheKey(roomId)
+	localCache.Delete(cacheKey)
+	if err := redis.GetClient().Del(ctx, cacheKey).Err(); err != nil {
+		return err
+	}
+	// 通知其他实例删除本地缓存
+	if err := cachebus.Publish(ctx, cacheKey); err != nil {
+		logger.FromContext(ctx).Error("Failed to publish cache invalidation", zap.String("key", cacheKey), zap.Error(err))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package goods
+
+import (
+	"context"
+	"goods_srv/audit"
+	"goods_srv/dao/mysql"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/proto"
+
+	"go.uber.org/zap"
+)
+
+// 直播间商品管理，修改绑定关系后删除直播间商品列表缓存，并向订阅者发布事件
+
+// defaultRoomGoodsWeight 绑定商品未指定权重时的默认值，与 xx_room_goods 表的默认值一致
+const defaultRoomGoodsWeight = 1000
+
+// BindGoodsToRoom 将商品绑定到直播间，weight 为 0 时使用默认权重
+func BindGoodsToRoom(ctx context.Context, roomId, goodsId, weight int64) error {
+	if weight == 0 {
+		weight = defaultRoomGoodsWeight
+	}
+	if err := mysql.BindGoodsToRoom(ctx, roomId, goodsId, weight, audit.Operator(ctx)); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Goods bound to room", zap.Int64("goods_id", goodsId), zap.Int64("room_id", roomId))
+	if err := invalidateRoomAfterWrite(ctx, roomId); err != nil {
+		return err
+	}
+	publishRoomEvent(ctx, roomId, goodsId, proto.RoomGoodsEventType_EVENT_GOODS_ADDED)
+	return nil
+}
+
+// UnbindGoodsFromRoom 解绑直播间的商品
+func UnbindGoodsFromRoom(ctx context.Context, roomId, goodsId int64) error {
+	if err := mysql.UnbindGoodsFromRoom(ctx, roomId, goodsId, audit.Operator(ctx)); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Goods unbound from room", zap.Int64("goods_id", goodsId), zap.Int64("room_id", roomId))
+	if err := invalidateRoomAfterWrite(ctx, roomId); err != nil {
+		return err
+	}
+	publishRoomEvent(ctx, roomId, goodsId, proto.RoomGoodsEventType_EVENT_GOODS_REMOVED)
+	return nil
+}
+
+// ReorderRoomGoods 按 goodsIds 的顺序重新排列直播间商品
+func ReorderRoomGoods(ctx context.Context, roomId int64, goodsIds []int64) error {
+	if err := mysql.ReorderRoomGoods(ctx, roomId, goodsIds, audit.Operator(ctx)); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Room goods reordered", zap.Int64("room_id", roomId))
+	if err := invalidateRoomAfterWrite(ctx, roomId); err != nil {
+		return err
+	}
+	publishRoomEvent(ctx, roomId, 0, proto.RoomGoodsEventType_EVENT_GOODS_REORDERED)
+	return nil
+}
+
+// SetCurrentGoods 切换直播间当前讲解的商品，goodsId 为 0 表示清除
+func SetCurrentGoods(ctx context.Context, roomId, goodsId int64) error {
+	if err := mysql.SetCurrentGoods(ctx, roomId, goodsId, audit.Operator(ctx)); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Room current goods switched", zap.Int64("room_id", roomId), zap.Int64("goods_id", goodsId))
+	if err := invalidateRoomAfterWrite(ctx, roomId); err != nil {
+		return err
+	}
+	publishRoomEvent(ctx, roomId, goodsId, proto.RoomGoodsEventType_EVENT_CURRENT_CHANGED)
+	return nil
+}
+
+// invalidateRoomAfterWrite 删除直播间商品列表缓存，删除失败时返回 ErrCacheDeleteFailed
+func invalidateRoomAfterWrite(ctx context.Context, roomId int64) error {
+	if err := InvalidateRoomCache(ctx, roomId); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete room cache", zap.Int64("room_id", roomId), zap.Error(err))
+		return errno.ErrCacheDeleteFailed
+	}
+	return nil
+}
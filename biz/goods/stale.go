@@ -0,0 +1,190 @@
+package goods
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"goods_srv/config"
+	"goods_srv/dao/redis"
+	"goods_srv/errno"
+	"goods_srv/localcache"
+	"goods_srv/logger"
+	"goods_srv/metrics"
+	"goods_srv/proto"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// 降级服务（stale-while-revalidate）
+// 1. 每次从数据库加载到新数据时，额外保存一份过期时间更长（max_staleness）的旧数据副本，
+//    本地保存在 staleCache 中，Redis 中保存在 stale_ 前缀的 key 中
+// 2. MySQL 或 Redis 故障导致回源失败时，返回旧数据副本并在响应中标记 Stale
+// 3. 返回旧数据后在后台按退避间隔重试回源，存储恢复后自动刷新缓存
+
+const (
+	staleKeyPrefix = "stale_"
+
+	// 后台重新回源的初始间隔和最大间隔
+	revalidateMinBackoff = time.Second
+	revalidateMaxBackoff = 30 * time.Second
+)
+
+var (
+	// staleCache 旧数据的本地副本，Redis 不可用时仍然可以降级
+	staleCache = localcache.New(localcache.Options{})
+
+	// revalidating 正在后台重新回源的缓存 key，避免同一个 key 启动多个 goroutine
+	revalidating sync.Map
+
+	staleServedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache",
+		Name:      "stale_served_total",
+		Help:      "存储故障时降级返回旧数据的次数",
+	}, []string{"kind"})
+)
+
+func init() {
+	metrics.MustRegister(staleServedTotal)
+}
+
+// maxStaleness 返回配置的旧数据最长保留时间，0 表示不降级
+func maxStaleness() time.Duration {
+	if cfg := config.Conf.CacheConfig; cfg != nil && cfg.MaxStaleness > 0 {
+		return cfg.MaxStaleness
+	}
+	return 0
+}
+
+// canServeStale 判断回源错误是否应该降级返回旧数据，商品确认不存在时不降级
+func canServeStale(err error) bool {
+	return maxStaleness() > 0 && !errors.Is(err, errno.ErrGoodsDetailNull)
+}
+
+// setStaleLocal 保存旧数据的本地副本
+func setStaleLocal(key string, value interface{}, size int64) {
+	if ttl := maxStaleness(); ttl > 0 {
+		staleCache.Set(key, value, size, ttl)
+	}
+}
+
+// setStaleRedis 保存旧数据的 Redis 副本，只在从数据库加载到新数据时调用
+func setStaleRedis(ctx context.Context, key string, data []byte) {
+	ttl := maxStaleness()
+	if ttl <= 0 {
+		return
+	}
+	if err := redis.GetClient().Set(ctx, staleKeyPrefix+key, data, ttl).Err(); err != nil {
+		logger.FromContext(ctx).Error("Failed to set stale copy in cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// deleteStaleCopy 删除旧数据副本，商品确认不存在时调用
+func deleteStaleCopy(ctx context.Context, key string) {
+	staleCache.Delete(key)
+	if maxStaleness() <= 0 {
+		return
+	}
+	if err := redis.GetClient().Del(ctx, staleKeyPrefix+key).Err(); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete stale copy in cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// getStaleData 依次从本地和 Redis 查询旧数据副本，本地命中时返回 value，Redis 命中时返回 data
+func getStaleData(ctx context.Context, key string) (value interface{}, data []byte, ok bool) {
+	if v, ok := staleCache.Get(key); ok {
+		return v, nil, true
+	}
+	data, err := redis.GetClient().Get(ctx, staleKeyPrefix+key).Bytes()
+	if err != nil {
+		return nil, nil, false
+	}
+	return nil, data, true
+}
+
+// getStaleGoodsDetail 查询商品详情的旧数据副本，返回的副本已标记 Stale
+func getStaleGoodsDetail(ctx context.Context, cacheKey string) (*proto.GoodsDetail, bool) {
+	value, data, ok := getStaleData(ctx, cacheKey)
+	if !ok {
+		return nil, false
+	}
+	goodsDetail, _ := value.(*proto.GoodsDetail)
+	if goodsDetail == nil {
+		var err error
+		if goodsDetail, err = decodeGoodsDetail(string(data)); err != nil {
+			logger.FromContext(ctx).Error("Failed to unmarshal stale data", zap.String("key", cacheKey), zap.Error(err))
+			return nil, false
+		}
+	}
+	// 本地副本与正常缓存共享同一个对象，复制后再修改
+	goodsDetail = gproto.Clone(goodsDetail).(*proto.GoodsDetail)
+	goodsDetail.Stale = true
+	staleServedTotal.WithLabelValues("goods_detail").Inc()
+	return goodsDetail, true
+}
+
+// getStaleRoomBinding 查询直播间绑定关系的旧数据副本
+func getStaleRoomBinding(ctx context.Context, cacheKey string) (*roomBinding, bool) {
+	value, data, ok := getStaleData(ctx, cacheKey)
+	if !ok {
+		return nil, false
+	}
+	binding, _ := value.(*roomBinding)
+	if binding == nil {
+		binding = &roomBinding{}
+		if err := json.Unmarshal(data, binding); err != nil {
+			logger.FromContext(ctx).Error("Failed to unmarshal stale room binding", zap.String("key", cacheKey), zap.Error(err))
+			return nil, false
+		}
+	}
+	staleServedTotal.WithLabelValues("room_goods").Inc()
+	return binding, true
+}
+
+// revalidate 在后台按退避间隔重试回源，直到成功或超过最长保留时间
+// 同一个 key 同时只会有一个 goroutine 在重试
+func revalidate(key string, load func(ctx context.Context) error) {
+	if _, loaded := revalidating.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+	go func() {
+		defer revalidating.Delete(key)
+		deadline := time.Now().Add(maxStaleness())
+		backoff := revalidateMinBackoff
+		for time.Now().Before(deadline) {
+			time.Sleep(backoff)
+			ctx, cancel := context.WithTimeout(context.Background(), revalidateMaxBackoff)
+			err := load(ctx)
+			cancel()
+			if err == nil {
+				logger.FromContext(ctx).Info("Revalidated stale cache", zap.String("key", key))
+				return
+			}
+			logger.FromContext(ctx).Warn("Failed to revalidate stale cache", zap.String("key", key), zap.Error(err))
+			backoff = min(backoff*2, revalidateMaxBackoff)
+		}
+	}()
+}
+
+// revalidateGoodsDetail 后台重新加载商品详情，商品已不存在时也视为完成
+func revalidateGoodsDetail(goodsId int64, cacheKey string) {
+	revalidate(cacheKey, func(ctx context.Context) error {
+		_, err := loadGoodsDetail(ctx, goodsId, cacheKey)
+		if errors.Is(err, errno.ErrGoodsDetailNull) {
+			return nil
+		}
+		return err
+	})
+}
+
+// revalidateRoomBinding 后台重新加载直播间绑定关系
+func revalidateRoomBinding(roomId int64, cacheKey string) {
+	revalidate(cacheKey, func(ctx context.Context) error {
+		_, err := loadRoomBinding(ctx, roomId, cacheKey)
+		return err
+	})
+}
@@ -0,0 +1,135 @@
+package goods
+
+import (
+	"context"
+	"fmt"
+	"goods_srv/bloomfilter"
+	"goods_srv/dao/mysql"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/proto"
+	"math"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// 按字段掩码部分更新商品
+// updatableFields 列出了允许更新的字段（GoodsDetail 中的字段名）及其校验和取值方式，
+// 字段掩码中出现其他字段时整个请求被拒绝。
+
+// updatableField 可更新字段的定义
+type updatableField struct {
+	column string                                              // 数据库列名
+	value  func(goods *proto.GoodsDetail) (interface{}, error) // 校验并返回要写入数据库的值
+}
+
+var updatableFields = map[string]updatableField{
+	"CategoryId": {"category_id", func(goods *proto.GoodsDetail) (interface{}, error) {
+		if goods.GetCategoryId() <= 0 {
+			return nil, errno.InvalidField("CategoryId", "CategoryId must be positive")
+		}
+		return goods.GetCategoryId(), nil
+	}},
+	"Title": {"title", func(goods *proto.GoodsDetail) (interface{}, error) {
+		if goods.GetTitle() == "" || len(goods.GetTitle()) > 255 {
+			return nil, errno.InvalidField("Title", "Title must be 1-255 bytes")
+		}
+		return goods.GetTitle(), nil
+	}},
+	"Code": {"code", func(goods *proto.GoodsDetail) (interface{}, error) {
+		if goods.GetCode() == "" || len(goods.GetCode()) > 64 {
+			return nil, errno.InvalidField("Code", "Code must be 1-64 bytes")
+		}
+		return goods.GetCode(), nil
+	}},
+	"BrandName": {"brand_name", func(goods *proto.GoodsDetail) (interface{}, error) {
+		if len(goods.GetBrandName()) > 255 {
+			return nil, errno.InvalidField("BrandName", "BrandName must be at most 255 bytes")
+		}
+		return goods.GetBrandName(), nil
+	}},
+	"MarketPrice": {"market_price", func(goods *proto.GoodsDetail) (interface{}, error) {
+		price, err := parsePrice(goods.GetMarketPrice())
+		if err != nil || price < 0 {
+			return nil, errno.InvalidField("MarketPrice", "MarketPrice must be a non-negative amount")
+		}
+		return price, nil
+	}},
+	"Price": {"price", func(goods *proto.GoodsDetail) (interface{}, error) {
+		price, err := parsePrice(goods.GetPrice())
+		if err != nil || price <= 0 {
+			return nil, errno.InvalidField("Price", "Price must be a positive amount")
+		}
+		return price, nil
+	}},
+	"Brief": {"brief", func(goods *proto.GoodsDetail) (interface{}, error) {
+		if len(goods.GetBrief()) > 255 {
+			return nil, errno.InvalidField("Brief", "Brief must be at most 255 bytes")
+		}
+		return goods.GetBrief(), nil
+	}},
+}
+
+// UpdateGoods 按字段掩码更新商品，返回更新后的商品详情
+// expectedVersion 不为 nil 时与数据库中的版本号比较，不一致时返回 VersionConflictError
+func UpdateGoods(ctx context.Context, goods *proto.GoodsDetail, paths []string, expectedVersion *uint16, reason string) (*proto.GoodsDetail, error) {
+	// 1. 校验字段掩码和字段值，转换为要更新的列
+	if len(paths) == 0 {
+		return nil, errno.InvalidUpdateMask("empty update mask")
+	}
+	fields := make(map[string]interface{}, len(paths))
+	for _, path := range paths {
+		if path == "Status" {
+			// 商品状态需要校验状态流转，只能通过 ChangeGoodsStatus 修改
+			return nil, errno.InvalidUpdateMask("Status must be changed by ChangeGoodsStatus")
+		}
+		field, ok := updatableFields[path]
+		if !ok {
+			return nil, errno.InvalidUpdateMask(fmt.Sprintf("unsupported path %q", path))
+		}
+		value, err := field.value(goods)
+		if err != nil {
+			return nil, err
+		}
+		fields[field.column] = value
+	}
+
+	// 2. 更新数据库，并记录修改日志
+	goodsId := goods.GetGoodsId()
+	if err := mysql.UpdateGoodsFields(ctx, goodsId, fields, expectedVersion, changeInfo(ctx, reason)); err != nil {
+		logger.FromContext(ctx).Error("Failed to update goods fields", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, err
+	}
+
+	// 3. 按配置的一致性策略删除或更新缓存
+	if err := syncGoodsCacheAfterWrite(ctx, goodsId); err != nil {
+		logger.FromContext(ctx).Error("Failed to delete cache", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return nil, errno.ErrCacheDeleteFailed
+	}
+	bloomfilter.Add(ctx, goodsId)
+
+	// 4. 返回数据库中最新的商品详情
+	updated, err := mysql.GetGoodsDetailById(ctx, goodsId)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, errno.ErrGoodsDetailNotFound
+	}
+
+	// 5. 修改了价格时通知订阅了直播间的客户端
+	if _, ok := fields["price"]; ok {
+		publishPriceChanged(ctx, goodsId, updated.Price)
+	}
+	return toGoodsDetailProto(updated), nil
+}
+
+// parsePrice 将以元为单位的价格字符串（例如 "12.50"）转换为以分为单位的整数
+func parsePrice(s string) (int64, error) {
+	yuan, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(yuan) || math.IsInf(yuan, 0) {
+		return 0, fmt.Errorf("invalid price %q", s)
+	}
+	return int64(math.Round(yuan * 100)), nil
+}
@@ -0,0 +1,136 @@
+package goods
+
+import (
+	"context"
+	"goods_srv/config"
+	"goods_srv/dao/mysql"
+	"goods_srv/logger"
+	"goods_srv/proto"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// 缓存预热
+// 新实例启动时本地缓存为空，开播瞬间大量请求会穿透到数据库。
+// 启动时预热正在直播的直播间商品，开播前也可以调用 WarmUpRoom 接口预热指定直播间。
+// 商品按批次加载，所有预热任务共享同一个并发限制，避免预热本身压垮数据库。
+
+const (
+	// 未配置时的默认值
+	defaultWarmUpConcurrency = 4
+	defaultWarmUpBatchSize   = 50
+	defaultWarmUpTimeout     = 30 * time.Second
+)
+
+var (
+	// warmUpSem 限制整个实例同时回源的预热批次数
+	warmUpSem = make(chan struct{}, defaultWarmUpConcurrency)
+	// warmUpBatchSize 每批预热的商品数
+	warmUpBatchSize = defaultWarmUpBatchSize
+)
+
+// InitWarmUp 根据配置初始化缓存预热，开启启动预热时同步预热正在直播的直播间
+func InitWarmUp(ctx context.Context, cfg *config.WarmUpConfig) {
+	if cfg == nil {
+		return
+	}
+	if cfg.Concurrency > 0 {
+		warmUpSem = make(chan struct{}, cfg.Concurrency)
+	}
+	if cfg.BatchSize > 0 {
+		warmUpBatchSize = cfg.BatchSize
+	}
+	if !cfg.OnBoot {
+		return
+	}
+	timeout := defaultWarmUpTimeout
+	if cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	warmUpActiveRooms(ctx)
+}
+
+// warmUpActiveRooms 预热所有正在直播的直播间，预热失败不影响服务启动
+func warmUpActiveRooms(ctx context.Context) {
+	start := time.Now()
+	roomIds, err := mysql.GetActiveRoomIds(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to query active rooms for warm-up", zap.Error(err))
+		return
+	}
+	for _, roomId := range roomIds {
+		var last *proto.WarmUpProgress
+		err := WarmUpRoom(ctx, roomId, func(p *proto.WarmUpProgress) error {
+			last = p
+			return nil
+		})
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to warm up room", zap.Int64("room_id", roomId), zap.Error(err))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		logger.FromContext(ctx).Info("Warmed up room", zap.Int64("room_id", roomId), zap.Int32("total", last.Total),
+			zap.Int32("loaded", last.Loaded), zap.Int32("not_found", last.NotFound), zap.Int32("failed", last.Failed))
+	}
+	logger.FromContext(ctx).Info("Warm-up finished", zap.Int("rooms", len(roomIds)), zap.Duration("elapsed", time.Since(start)))
+}
+
+// WarmUpRoom 预热直播间绑定的商品，每完成一批调用一次 report 汇报进度，最后一次汇报的 Done 为 true
+// report 返回错误时停止预热
+func WarmUpRoom(ctx context.Context, roomId int64, report func(p *proto.WarmUpProgress) error) error {
+	// 1. 加载直播间绑定关系
+	binding, _, err := getRoomBinding(ctx, roomId)
+	if err != nil {
+		return err
+	}
+	goodsIds := binding.GoodsIds
+	progress := &proto.WarmUpProgress{RoomId: roomId, Total: int32(len(goodsIds))}
+	if err := report(progress); err != nil {
+		return err
+	}
+
+	// 2. 分批加载商品详情到 Redis 和本地缓存
+	var mu sync.Mutex
+	g, gctx := errgroup.WithContext(ctx)
+	for start := 0; start < len(goodsIds) && gctx.Err() == nil; start += warmUpBatchSize {
+		batch := goodsIds[start:min(start+warmUpBatchSize, len(goodsIds))]
+		select {
+		case warmUpSem <- struct{}{}:
+		case <-gctx.Done():
+			continue
+		}
+		g.Go(func() error {
+			defer func() { <-warmUpSem }()
+			details, err := getGoodsDetails(gctx, batch)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logger.FromContext(ctx).Error("Failed to warm up goods batch", zap.Int64("room_id", roomId), zap.Error(err))
+				progress.Failed += int32(len(batch))
+			} else {
+				progress.Loaded += int32(len(details.found))
+				progress.Failed += int32(len(details.unavailable))
+				progress.NotFound += int32(len(batch) - len(details.found) - len(details.unavailable))
+			}
+			return report(progress)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// 3. 汇报最终结果
+	progress.Done = true
+	return report(progress)
+}
@@ -0,0 +1,150 @@
+package goods
+
+import (
+	"context"
+	"errors"
+	"goods_srv/config"
+	"goods_srv/dao/mysql"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/proto"
+	"goods_srv/roomwatch"
+
+	"go.uber.org/zap"
+)
+
+// 直播间商品订阅
+// 客户端订阅后先收到直播间商品列表的快照，之后收到切换讲解商品、上下架、排序和改价的增量事件。
+// 每个事件带有 ResumeToken，断线重连时传入最后收到的 ResumeToken 补发错过的事件，
+// 无法补发（事件已被裁剪）或消费太慢丢失事件时，重新发送快照。
+
+// InitRoomWatch 根据配置订阅直播间商品事件
+func InitRoomWatch(ctx context.Context, cfg *config.RoomWatchConfig) {
+	var (
+		bufferSize   int
+		streamMaxLen int64
+	)
+	if cfg != nil {
+		bufferSize = cfg.BufferSize
+		streamMaxLen = cfg.StreamMaxLen
+	}
+	roomwatch.Start(ctx, bufferSize, streamMaxLen)
+}
+
+// WatchRoomGoods 订阅直播间商品变更，直到客户端取消或发送失败
+func WatchRoomGoods(ctx context.Context, roomId int64, resumeToken string, send func(ev *proto.RoomGoodsEvent) error) error {
+	// 先订阅再读取快照或补发事件，保证期间发布的事件不会遗漏
+	w := roomwatch.Watch(roomId)
+	defer w.Close()
+
+	// last 已推送给客户端的最新 ResumeToken，订阅期间收到的不晚于它的事件已包含在快照或补发中
+	var last string
+	replayed := false
+	if resumeToken != "" {
+		events, ok, err := roomwatch.Replay(ctx, roomId, resumeToken)
+		if err != nil {
+			return err
+		}
+		if ok {
+			last = resumeToken
+			for _, ev := range events {
+				if err := send(ev); err != nil {
+					return err
+				}
+				last = ev.ResumeToken
+			}
+			replayed = true
+		} else {
+			logger.FromContext(ctx).Info("Resume token expired, send snapshot", zap.String("resume_token", resumeToken), zap.Int64("room_id", roomId))
+		}
+	}
+	if !replayed {
+		token, err := sendRoomSnapshot(ctx, roomId, send)
+		if err != nil {
+			return err
+		}
+		last = token
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.Lagged():
+			// 消费太慢或订阅断开丢失了事件，丢弃缓冲的事件并重新发送快照
+			logger.FromContext(ctx).Warn("Watcher lagged, resend snapshot", zap.Int64("room_id", roomId))
+			w.Drain()
+			token, err := sendRoomSnapshot(ctx, roomId, send)
+			if err != nil {
+				return err
+			}
+			last = token
+		case ev := <-w.Events():
+			if !roomwatch.After(ev.ResumeToken, last) {
+				continue
+			}
+			if err := send(ev); err != nil {
+				return err
+			}
+			last = ev.ResumeToken
+		}
+	}
+}
+
+// sendRoomSnapshot 发送直播间商品列表快照，返回快照对应的 ResumeToken
+// 先取最新的 ResumeToken 再读取商品列表，快照包含该 token 之前的所有变更，之后的事件可能重复推送但不会遗漏
+func sendRoomSnapshot(ctx context.Context, roomId int64, send func(ev *proto.RoomGoodsEvent) error) (string, error) {
+	token, err := roomwatch.LatestToken(ctx, roomId)
+	if err != nil {
+		return "", err
+	}
+	snapshot, err := GetGoodsByRoom(ctx, roomId, false)
+	if errors.Is(err, errno.ErrGoodsNotExist) {
+		// 直播间的商品全部不存在，发送空列表
+		snapshot, err = &proto.GoodsListResp{}, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	err = send(&proto.RoomGoodsEvent{
+		Type:        proto.RoomGoodsEventType_EVENT_SNAPSHOT,
+		ResumeToken: token,
+		RoomId:      roomId,
+		Snapshot:    snapshot,
+	})
+	return token, err
+}
+
+// publishRoomEvent 发布直播间商品事件，发布失败只记录日志，不影响写操作的结果
+func publishRoomEvent(ctx context.Context, roomId, goodsId int64, typ proto.RoomGoodsEventType) {
+	publishRoomEventWithPrice(ctx, roomId, goodsId, typ, "")
+}
+
+func publishRoomEventWithPrice(ctx context.Context, roomId, goodsId int64, typ proto.RoomGoodsEventType, price string) {
+	err := roomwatch.Publish(ctx, &proto.RoomGoodsEvent{
+		Type:    typ,
+		RoomId:  roomId,
+		GoodsId: goodsId,
+		Price:   price,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to publish room goods event", zap.Stringer("type", typ), zap.Int64("room_id", roomId), zap.Error(err))
+	}
+}
+
+// publishGoodsEvent 向绑定了该商品的所有直播间发布事件
+func publishGoodsEvent(ctx context.Context, goodsId int64, typ proto.RoomGoodsEventType, price string) {
+	roomIds, err := mysql.GetRoomIdsByGoodsId(ctx, goodsId)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to query rooms of goods", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return
+	}
+	for _, roomId := range roomIds {
+		publishRoomEventWithPrice(ctx, roomId, goodsId, typ, price)
+	}
+}
+
+// publishPriceChanged 向绑定了该商品的所有直播间发布改价事件，price 以分为单位
+func publishPriceChanged(ctx context.Context, goodsId, price int64) {
+	publishGoodsEvent(ctx, goodsId, proto.RoomGoodsEventType_EVENT_PRICE_CHANGED, formatPrice(price))
+}
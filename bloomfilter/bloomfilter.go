@@ -3,33 +3,327 @@ package bloomfilter
 import (
 	"context"
 	"fmt"
+	"goods_srv/config"
 	"goods_srv/dao/mysql"
-	"log"
+	"goods_srv/logger"
+	"goods_srv/metrics"
+	"math"
+	"os"
+	"sync/atomic"
+	"time"
 
-	"github.com/willf/bloom"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 )
 
+const (
+	// syncInterval 增量同步新商品ID的时间间隔
+	syncInterval = time.Minute
+	// defaultRebuildInterval 未配置时全量重建的默认间隔
+	defaultRebuildInterval = 6 * time.Hour
+
+	// 未配置时默认的预计商品数量和误判率
+	defaultExpectedItems     = 1000000 // 预计商品总数
+	defaultFalsePositiveRate = 0.0001  // 误判率 0.01%
+)
+
+// Filter 布隆过滤器接口，本地内存和 Redis 位图两种实现
+type Filter interface {
+	// Add 将商品ID加入过滤器
+	Add(ctx context.Context, goodsId int64) error
+	// MightContain 返回 false 表示商品一定不存在；返回 true 表示商品可能存在
+	MightContain(ctx context.Context, goodsId int64) (bool, error)
+	// Rebuild 使用 loader 加载的全量商品ID构建新过滤器，并原子地替换旧过滤器
+	// 重建期间调用 Add 写入的商品ID同样会进入新过滤器
+	Rebuild(ctx context.Context, loader func(ctx context.Context) ([]int64, error)) error
+	// Stats 返回过滤器当前的填充情况
+	Stats(ctx context.Context) (*Stats, error)
+}
+
+// Stats 布隆过滤器的填充情况
+type Stats struct {
+	Bits            uint    // 位数组大小 m
+	Hashes          uint    // 哈希函数个数 k
+	SetBits         uint    // 已置为 1 的位数
+	FillRatio       float64 // 填充率 SetBits/Bits
+	EstimatedFPRate float64 // 按当前填充率估算的误判率 FillRatio^k
+}
+
+func newStats(m, k, setBits uint) *Stats {
+	fillRatio := float64(setBits) / float64(m)
+	return &Stats{
+		Bits:            m,
+		Hashes:          k,
+		SetBits:         setBits,
+		FillRatio:       fillRatio,
+		EstimatedFPRate: math.Pow(fillRatio, float64(k)),
+	}
+}
+
 var (
-	goodsbloomfiltyer *bloom.BloomFilter //本地布隆过滤器实例
+	filter Filter        // 当前使用的布隆过滤器实现
+	lastPK atomic.Uint64 // 已加载到过滤器中的最大主键ID，用于增量同步
+
+	// newGoodsHook 增量同步发现新商品时的回调，用于清理该商品的空值缓存
+	newGoodsHook func(ctx context.Context, goodsId int64) error
+
+	// 过滤器填充情况的监控指标，统计需要遍历整个位数组，由后台定时刷新而不是每次采集时计算
+	fillRatioGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "bloom_filter",
+		Name:      "fill_ratio",
+		Help:      "布隆过滤器位数组中置为 1 的位的比例",
+	})
+	estimatedFPRateGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "bloom_filter",
+		Name:      "estimated_false_positive_rate",
+		Help:      "按当前填充率估算的误判率",
+	})
+	setBitsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "bloom_filter",
+		Name:      "set_bits",
+		Help:      "布隆过滤器位数组中置为 1 的位数",
+	})
 )
 
+// OnNewGoods 注册增量同步发现新商品时的回调，需在 InitBloomFilter 之前调用
+func OnNewGoods(fn func(ctx context.Context, goodsId int64) error) {
+	newGoodsHook = fn
+}
+
 func InitBloomFilter(ctx context.Context) error {
-	// 预计插入的商品ID数量和误判率
-	estimatedItems := 1000000 // 预计商品总数
-	errorRate := 0.0001       // 误判率 0.01%
-	goodsbloomfiltyer = bloom.New(uint(estimatedItems), uint(errorRate))
+	cfg := bloomFilterConfig()
 
-	//从数据库加载所有商品ID
-	goodsIDs, err := mysql.GetAllGoodsIDs(ctx)
+	// 根据配置的预计商品数量和误判率计算位数组大小和哈希函数个数
+	n, fp := cfg.ExpectedItems, cfg.FalsePositiveRate
+	if n == 0 {
+		n = defaultExpectedItems
+	}
+	if fp <= 0 || fp >= 1 {
+		fp = defaultFalsePositiveRate
+	}
+
+	// 先记录当前最大主键，之后新插入的商品由增量同步负责加载
+	maxPK, err := mysql.GetMaxGoodsPK(ctx)
 	if err != nil {
-		log.Fatalf("Failed to load goods IDs from database: %v", err)
+		return fmt.Errorf("load max goods pk failed: %w", err)
 	}
+	lastPK.Store(uint64(maxPK))
 
-	// 将商品ID添加到布隆过滤器中
-	for _, goodsID := range goodsIDs {
-		goodsbloomfiltyer.Add([]byte(fmt.Sprintf("%d", goodsID)))
+	// 根据配置选择过滤器实现
+	needRebuild := true
+	switch cfg.Type {
+	case "redis":
+		rf := newRedisFilter(n, fp)
+		// Redis 中已有其他实例构建好的过滤器时直接复用，不再全量扫表
+		// 从位图记录的最大主键继续增量同步，补齐没有实例运行期间新增的商品
+		if ok, err := rf.ready(ctx); err == nil && ok {
+			if pk, ok, err := rf.syncedPK(ctx); err == nil && ok {
+				lastPK.Store(pk)
+				needRebuild = false
+			}
+		}
+		filter = rf
+	default:
+		lf := newLocalFilter(n, fp)
+		// 从快照恢复时跳过全量扫表，快照之后新增的商品由增量同步补齐
+		if cfg.SnapshotPath != "" {
+			pk, err := lf.loadSnapshot(cfg.SnapshotPath)
+			if err == nil {
+				lastPK.Store(uint64(pk))
+				needRebuild = false
+				logger.FromContext(ctx).Info("Bloom filter loaded from snapshot", zap.String("path", cfg.SnapshotPath))
+			} else if !os.IsNotExist(err) {
+				logger.FromContext(ctx).Warn("Failed to load bloom filter snapshot, rebuild from database", zap.Error(err))
+			}
+		}
+		filter = lf
 	}
 
-	log.Printf("Bloom filter initialized with %d goods IDs", len(goodsIDs))
+	if needRebuild {
+		if err := filter.Rebuild(ctx, loadAllGoodsIDs); err != nil {
+			return fmt.Errorf("load goods IDs from database failed: %w", err)
+		}
+		saveSyncedPK(ctx)
+	} else {
+		syncNewGoods(ctx)
+	}
+	logger.FromContext(ctx).Info("Bloom filter initialized", zap.String("type", cfg.Type))
+	metrics.MustRegister(fillRatioGauge, estimatedFPRateGauge, setBitsGauge)
+	logStats(ctx)
+
+	// 启动增量同步，保证启动后新创建的商品不会被误拒
+	go syncLoop(ctx)
+	// 启动定时全量重建，清理已删除商品残留的位
+	go rebuildLoop(ctx, cfg.RebuildInterval)
 	return nil
 }
+
+// SaveSnapshot 将本地布隆过滤器写入快照文件，服务退出时调用
+// 使用 Redis 实现时数据由 Redis 持久化，无需快照
+func SaveSnapshot() error {
+	lf, ok := filter.(*localFilter)
+	path := bloomFilterConfig().SnapshotPath
+	if !ok || path == "" {
+		return nil
+	}
+	return lf.saveSnapshot(path, uint(lastPK.Load()))
+}
+
+// bloomFilterConfig 返回布隆过滤器配置，未配置时使用默认值
+func bloomFilterConfig() *config.BloomFilterConfig {
+	if cfg := config.Conf.BloomFilterConfig; cfg != nil {
+		return cfg
+	}
+	return &config.BloomFilterConfig{}
+}
+
+// Add 将商品ID加入布隆过滤器
+func Add(ctx context.Context, goodsId int64) {
+	if filter == nil {
+		return
+	}
+	if err := filter.Add(ctx, goodsId); err != nil {
+		logger.FromContext(ctx).Warn("Failed to add goods to bloom filter", zap.Int64("goods_id", goodsId), zap.Error(err))
+	}
+}
+
+// AddNewGoods 将其他实例广播的新增商品ID加入本地过滤器，不用等增量同步
+// Redis 实现由新增商品的实例直接写入共享位图，无需处理
+func AddNewGoods(goodsIds []int64) {
+	if _, ok := filter.(*localFilter); !ok {
+		return
+	}
+	for _, goodsId := range goodsIds {
+		Add(context.Background(), goodsId)
+	}
+}
+
+// MightContain 判断商品ID是否可能存在
+// 返回 false 表示商品一定不存在；返回 true 表示商品可能存在（存在误判）
+// 过滤器尚未初始化或查询出错时一律返回 true，避免误拒正常请求
+func MightContain(ctx context.Context, goodsId int64) bool {
+	if filter == nil {
+		return true
+	}
+	ok, err := filter.MightContain(ctx, goodsId)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to test goods in bloom filter", zap.Int64("goods_id", goodsId), zap.Error(err))
+		return true
+	}
+	return ok
+}
+
+// loadAllGoodsIDs 从数据库加载所有商品ID
+func loadAllGoodsIDs(ctx context.Context) ([]int64, error) {
+	goodsIDs, err := mysql.GetAllGoodsIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Bloom filter loaded goods IDs", zap.Int("count", len(goodsIDs)))
+	return goodsIDs, nil
+}
+
+// syncLoop 定时把主键大于 lastPK 的新商品加入布隆过滤器，并刷新填充情况的监控指标
+func syncLoop(ctx context.Context) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			syncNewGoods(ctx)
+			if _, err := refreshStats(ctx); err != nil {
+				logger.FromContext(ctx).Error("Failed to get bloom filter stats", zap.Error(err))
+			}
+		}
+	}
+}
+
+// syncNewGoods 把主键大于 lastPK 的新商品加入布隆过滤器
+func syncNewGoods(ctx context.Context) {
+	goodsList, err := mysql.GetGoodsIDsAfter(ctx, uint(lastPK.Load()))
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to sync new goods IDs", zap.Error(err))
+		return
+	}
+	for _, goods := range goodsList {
+		Add(ctx, goods.GoodsId)
+		if newGoodsHook != nil {
+			if err := newGoodsHook(ctx, goods.GoodsId); err != nil {
+				logger.FromContext(ctx).Error("Failed to run new goods hook", zap.Int64("goods_id", goods.GoodsId), zap.Error(err))
+			}
+		}
+		if uint64(goods.ID) > lastPK.Load() {
+			lastPK.Store(uint64(goods.ID))
+		}
+	}
+	if len(goodsList) > 0 {
+		saveSyncedPK(ctx)
+		logger.FromContext(ctx).Info("Bloom filter synced new goods IDs", zap.Int("count", len(goodsList)))
+	}
+}
+
+// saveSyncedPK 使用 Redis 实现时记录已加载到共享位图中的最大主键，供其他实例启动时继续增量同步
+func saveSyncedPK(ctx context.Context) {
+	rf, ok := filter.(*redisFilter)
+	if !ok {
+		return
+	}
+	if err := rf.saveSyncedPK(ctx, lastPK.Load()); err != nil {
+		logger.FromContext(ctx).Error("Failed to save bloom filter synced pk", zap.Error(err))
+	}
+}
+
+// rebuildLoop 定时全量重建布隆过滤器
+func rebuildLoop(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultRebuildInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := filter.Rebuild(ctx, loadAllGoodsIDs); err != nil {
+				logger.FromContext(ctx).Error("Failed to rebuild bloom filter", zap.Error(err))
+				continue
+			}
+			logger.FromContext(ctx).Info("Bloom filter rebuilt")
+			logStats(ctx)
+		}
+	}
+}
+
+// refreshStats 读取布隆过滤器的填充情况并更新监控指标
+func refreshStats(ctx context.Context) (*Stats, error) {
+	stats, err := filter.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fillRatioGauge.Set(stats.FillRatio)
+	estimatedFPRateGauge.Set(stats.EstimatedFPRate)
+	setBitsGauge.Set(float64(stats.SetBits))
+	return stats, nil
+}
+
+// logStats 刷新监控指标，并记录布隆过滤器的填充率和估算误判率
+func logStats(ctx context.Context) {
+	stats, err := refreshStats(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get bloom filter stats", zap.Error(err))
+		return
+	}
+	logger.FromContext(ctx).Info("Bloom filter stats", zap.Uint("bits", stats.Bits), zap.Uint("hashes", stats.Hashes),
+		zap.Uint("set_bits", stats.SetBits), zap.Float64("fill_ratio", stats.FillRatio), zap.Float64("estimated_fp_rate", stats.EstimatedFPRate))
+}
+
+// goodsKey 构造写入布隆过滤器的商品ID
+func goodsKey(goodsId int64) []byte {
+	return []byte(fmt.Sprintf("%d", goodsId))
+}
@@ -0,0 +1,178 @@
+package bloomfilter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/willf/bitset"
+	"github.com/willf/bloom"
+)
+
+// snapshotMagic 快照文件头，用于识别文件格式
+const snapshotMagic = "GBF1"
+
+// localFilter 基于进程内存的布隆过滤器，每个实例各自维护一份
+type localFilter struct {
+	mu                sync.RWMutex       // bloom.BloomFilter 本身不是并发安全的
+	goodsbloomfiltyer *bloom.BloomFilter //本地布隆过滤器实例
+	m, k              uint               // 位数组大小和哈希函数个数
+	rebuilding        bool               // 是否正在重建
+	pending           []int64            // 重建期间新增的商品ID，重建完成后补入新过滤器
+}
+
+var _ Filter = (*localFilter)(nil)
+
+func newLocalFilter(n uint, fp float64) *localFilter {
+	f := &localFilter{}
+	f.m, f.k = bloom.EstimateParameters(n, fp)
+	f.goodsbloomfiltyer = bloom.New(f.m, f.k)
+	return f
+}
+
+func (f *localFilter) Add(ctx context.Context, goodsId int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.goodsbloomfiltyer.Add(goodsKey(goodsId))
+	if f.rebuilding {
+		f.pending = append(f.pending, goodsId)
+	}
+	return nil
+}
+
+func (f *localFilter) MightContain(ctx context.Context, goodsId int64) (bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.goodsbloomfiltyer.Test(goodsKey(goodsId)), nil
+}
+
+func (f *localFilter) Rebuild(ctx context.Context, loader func(ctx context.Context) ([]int64, error)) error {
+	f.mu.Lock()
+	f.rebuilding = true
+	f.pending = nil
+	f.mu.Unlock()
+
+	goodsIDs, err := loader(ctx)
+	if err != nil {
+		f.mu.Lock()
+		f.rebuilding = false
+		f.pending = nil
+		f.mu.Unlock()
+		return err
+	}
+
+	// 在锁外构建新过滤器，避免长时间阻塞读请求
+	fresh := bloom.New(f.m, f.k)
+	for _, goodsID := range goodsIDs {
+		fresh.Add(goodsKey(goodsID))
+	}
+
+	// 补入重建期间新增的商品ID后原子替换
+	f.mu.Lock()
+	for _, goodsID := range f.pending {
+		fresh.Add(goodsKey(goodsID))
+	}
+	f.goodsbloomfiltyer = fresh
+	f.rebuilding = false
+	f.pending = nil
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *localFilter) Stats(ctx context.Context) (*Stats, error) {
+	// bloom.BloomFilter 没有暴露底层位数组，通过序列化结果统计置位数
+	var buf bytes.Buffer
+	f.mu.RLock()
+	_, err := f.goodsbloomfiltyer.WriteTo(&buf)
+	f.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	// 跳过 m、k 两个 uint64 头部
+	buf.Next(2 * binary.Size(uint64(0)))
+	b := &bitset.BitSet{}
+	if _, err := b.ReadFrom(&buf); err != nil {
+		return nil, err
+	}
+	return newStats(f.m, f.k, b.Count()), nil
+}
+
+// saveSnapshot 将过滤器和已加载的最大主键写入快照文件
+// 先写临时文件再重命名，避免退出过程中被中断留下损坏的快照
+func (f *localFilter) saveSnapshot(path string, pk uint) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// 任何一步写入失败都不重命名，保留原来的快照
+	err = f.writeSnapshot(tmp, pk)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// writeSnapshot 依次写入快照标识、最大主键和过滤器数据
+func (f *localFilter) writeSnapshot(dst io.Writer, pk uint) error {
+	w := bufio.NewWriter(dst)
+	if _, err := w.WriteString(snapshotMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint64(pk)); err != nil {
+		return err
+	}
+	f.mu.RLock()
+	_, err := f.goodsbloomfiltyer.WriteTo(w)
+	f.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// loadSnapshot 从快照文件恢复过滤器，返回快照中记录的最大主键
+// 快照的 m、k 与当前配置不一致时视为无效，需要重新从数据库构建
+func (f *localFilter) loadSnapshot(path string) (uint, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || string(magic) != snapshotMagic {
+		return 0, fmt.Errorf("invalid bloom filter snapshot: %s", path)
+	}
+	var pk uint64
+	if err := binary.Read(r, binary.BigEndian, &pk); err != nil {
+		return 0, err
+	}
+	loaded := &bloom.BloomFilter{}
+	if _, err := loaded.ReadFrom(r); err != nil {
+		return 0, err
+	}
+	if loaded.Cap() != f.m || loaded.K() != f.k {
+		return 0, fmt.Errorf("bloom filter snapshot parameters mismatch: m=%d k=%d, want m=%d k=%d",
+			loaded.Cap(), loaded.K(), f.m, f.k)
+	}
+
+	f.mu.Lock()
+	f.goodsbloomfiltyer = loaded
+	f.mu.Unlock()
+	return uint(pk), nil
+}
@@ -0,0 +1,186 @@
+package bloomfilter
+
+import (
+	"context"
+	"fmt"
+	"goods_srv/dao/redis"
+	"sync"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/willf/bloom"
+)
+
+const (
+	// currentKey 保存当前生效的位图 key
+	currentKey = "goods_bloom_current"
+	// buildingKey 保存正在重建的位图 key，重建期间的新增商品需要同时写入
+	buildingKey = "goods_bloom_building"
+	// rebuildLockKey 全量重建的分布式锁，保证同一时刻只有一个实例在重建
+	rebuildLockKey = "lock_goods_bloom_rebuild"
+	// syncedPKKey 已加载到共享位图中的最大主键，实例启动复用位图时从这里继续增量同步
+	syncedPKKey = "goods_bloom_synced_pk"
+
+	// rebuildTimeout 重建的最长耗时，超过后 buildingKey 和分布式锁自动失效
+	rebuildTimeout = 10 * time.Minute
+	// pointerRefresh 本地缓存 currentKey 的时间，替换后旧位图在此期间内仍然可读
+	pointerRefresh = 10 * time.Second
+)
+
+// redisFilter 基于 Redis 位图的布隆过滤器，所有实例共享同一份数据
+type redisFilter struct {
+	m, k uint // 位数组大小和哈希函数个数
+
+	mu        sync.Mutex
+	current   string    // 本地缓存的当前位图 key
+	refreshAt time.Time // 上次刷新 current 的时间
+}
+
+var _ Filter = (*redisFilter)(nil)
+
+func newRedisFilter(n uint, fp float64) *redisFilter {
+	m, k := bloom.EstimateParameters(n, fp)
+	return &redisFilter{m: m, k: k}
+}
+
+// ready 判断 Redis 中是否已经存在可用的位图
+func (f *redisFilter) ready(ctx context.Context) (bool, error) {
+	key, err := f.currentBitmap(ctx)
+	return key != "", err
+}
+
+// syncedPK 返回已加载到共享位图中的最大主键，ok 为 false 表示没有记录
+func (f *redisFilter) syncedPK(ctx context.Context) (pk uint64, ok bool, err error) {
+	pk, err = redis.GetClient().Get(ctx, syncedPKKey).Uint64()
+	if err == goredis.Nil {
+		return 0, false, nil
+	}
+	return pk, err == nil, err
+}
+
+// saveSyncedPK 记录已加载到共享位图中的最大主键
+func (f *redisFilter) saveSyncedPK(ctx context.Context, pk uint64) error {
+	return redis.SetMax(ctx, syncedPKKey, pk)
+}
+
+func (f *redisFilter) Add(ctx context.Context, goodsId int64) error {
+	// 写请求较少，每次都读取最新的指针，保证写入正在重建的位图
+	keys, err := redis.GetClient().MGet(ctx, currentKey, buildingKey).Result()
+	if err != nil {
+		return err
+	}
+	offsets := f.offsets(goodsId)
+	for _, key := range keys {
+		if k, ok := key.(string); ok && k != "" {
+			if err := redis.SetBits(ctx, k, offsets); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f *redisFilter) MightContain(ctx context.Context, goodsId int64) (bool, error) {
+	key, err := f.currentBitmap(ctx)
+	if err != nil {
+		return true, err
+	}
+	if key == "" {
+		// 位图尚未构建，不能判定商品不存在
+		return true, nil
+	}
+	return redis.TestBits(ctx, key, f.offsets(goodsId))
+}
+
+func (f *redisFilter) Rebuild(ctx context.Context, loader func(ctx context.Context) ([]int64, error)) (err error) {
+	mutex := redis.Rs.NewMutex(rebuildLockKey, redsync.WithExpiry(rebuildTimeout), redsync.WithTries(1))
+	if err := mutex.LockContext(ctx); err != nil {
+		// 其他实例正在重建，本次跳过
+		return nil
+	}
+	defer mutex.UnlockContext(ctx)
+
+	rc := redis.GetClient()
+	newKey := fmt.Sprintf("goods_bloom_%d", time.Now().UnixNano())
+	if err = rc.Set(ctx, buildingKey, newKey, rebuildTimeout).Err(); err != nil {
+		return err
+	}
+	defer func() {
+		rc.Del(ctx, buildingKey)
+		if err != nil {
+			// 重建失败，清理构建了一半的位图
+			rc.Del(ctx, newKey)
+		}
+	}()
+
+	goodsIDs, err := loader(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 在内存中构建完整位图后一次性合并到 Redis，避免上百万次 SETBIT
+	bitmap := make([]byte, (f.m+7)/8)
+	for _, goodsID := range goodsIDs {
+		for _, offset := range f.offsets(goodsID) {
+			bitmap[offset/8] |= 1 << (7 - offset%8)
+		}
+	}
+	if err = redis.MergeBitmap(ctx, newKey, bitmap, rebuildTimeout); err != nil {
+		return err
+	}
+
+	// 切换指针，旧位图延迟过期，保证仍在使用旧指针的实例可以继续读取
+	oldKey, err := rc.GetSet(ctx, currentKey, newKey).Result()
+	if err != nil && err != goredis.Nil {
+		return err
+	}
+	err = nil
+	if oldKey != "" && oldKey != newKey {
+		rc.Expire(ctx, oldKey, 2*pointerRefresh)
+	}
+
+	f.mu.Lock()
+	f.current, f.refreshAt = newKey, time.Now()
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *redisFilter) Stats(ctx context.Context) (*Stats, error) {
+	key, err := f.currentBitmap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return newStats(f.m, f.k, 0), nil
+	}
+	setBits, err := redis.CountBits(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return newStats(f.m, f.k, uint(setBits)), nil
+}
+
+// currentBitmap 返回当前生效的位图 key，本地缓存 pointerRefresh 时间
+func (f *redisFilter) currentBitmap(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.current != "" && time.Since(f.refreshAt) < pointerRefresh {
+		return f.current, nil
+	}
+	key, err := redis.GetClient().Get(ctx, currentKey).Result()
+	if err != nil && err != goredis.Nil {
+		return f.current, err
+	}
+	f.current, f.refreshAt = key, time.Now()
+	return key, nil
+}
+
+// offsets 计算商品ID在位图中对应的 k 个偏移量
+func (f *redisFilter) offsets(goodsId int64) []uint64 {
+	locations := bloom.Locations(goodsKey(goodsId), f.k)
+	for i := range locations {
+		locations[i] %= uint64(f.m)
+	}
+	return locations
+}
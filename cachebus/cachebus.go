@@ -0,0 +1,151 @@
+package cachebus
+
+import (
+	"context"
+	"encoding/json"
+	"goods_srv/dao/redis"
+	"goods_srv/logger"
+	"goods_srv/metrics"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// 缓存失效总线
+// 写操作删除缓存后向 Redis 频道发布失效消息，所有 goods_srv 实例订阅该频道并删除对应的本地缓存
+
+const (
+	// channel 失效消息的 Redis 频道
+	channel = "goods_cache_invalidate"
+
+	// 订阅断开后的重连退避时间
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// Message 失效消息
+type Message struct {
+	Keys        []string `json:"keys"`                // 需要删除的缓存 key
+	NewGoods    []int64  `json:"new_goods,omitempty"` // 新增的商品ID，收到后加入本实例的布隆过滤器
+	Source      string   `json:"source"`              // 发布消息的实例ID
+	PublishedAt int64    `json:"published_at"`        // 发布时间（毫秒时间戳），用于计算失效延迟
+}
+
+var (
+	instanceId string // 当前实例ID
+
+	invalidationLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache_bus",
+		Name:      "invalidation_lag_seconds",
+		Help:      "失效消息从发布到本实例删除本地缓存的延迟",
+		Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 5},
+	})
+	receivedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache_bus",
+		Name:      "messages_received_total",
+		Help:      "收到的失效消息数",
+	})
+	reconnectsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache_bus",
+		Name:      "reconnects_total",
+		Help:      "订阅断开后重连的次数",
+	})
+)
+
+// Start 订阅失效消息
+// onEvict 收到失效消息时删除本地缓存
+// onReset 订阅断开重连后调用，断开期间可能错过失效消息，需要清空本地缓存
+// onNewGoods 收到新增商品消息时将商品加入本实例的布隆过滤器
+func Start(ctx context.Context, id string, onEvict func(keys []string), onReset func(), onNewGoods func(goodsIds []int64)) {
+	instanceId = id
+	metrics.MustRegister(invalidationLag, receivedTotal, reconnectsTotal)
+	go subscribeLoop(ctx, &handlers{onEvict: onEvict, onReset: onReset, onNewGoods: onNewGoods})
+}
+
+// Publish 发布失效消息，通知所有实例删除对应的本地缓存
+func Publish(ctx context.Context, keys ...string) error {
+	return publish(ctx, &Message{Keys: keys})
+}
+
+// PublishNewGoods 发布新增商品消息，通知所有实例将商品加入本地布隆过滤器，
+// 避免其他实例在增量同步之前误拒新商品
+func PublishNewGoods(ctx context.Context, goodsIds ...int64) error {
+	return publish(ctx, &Message{NewGoods: goodsIds})
+}
+
+func publish(ctx context.Context, msg *Message) error {
+	msg.Source = instanceId
+	msg.PublishedAt = time.Now().UnixMilli()
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return redis.GetClient().Publish(ctx, channel, data).Err()
+}
+
+// handlers 收到消息时的回调
+type handlers struct {
+	onEvict    func(keys []string)
+	onReset    func()
+	onNewGoods func(goodsIds []int64)
+}
+
+// subscribeLoop 订阅失效频道，断开后按指数退避重连
+func subscribeLoop(ctx context.Context, h *handlers) {
+	backoff := minBackoff
+	connected := false
+	for ctx.Err() == nil {
+		ps := redis.GetClient().Subscribe(ctx, channel)
+		// 等待订阅确认，确认之后才算重连成功
+		if _, err := ps.Receive(ctx); err != nil {
+			logger.FromContext(ctx).Error("Failed to subscribe cache invalidation channel", zap.Error(err))
+			ps.Close()
+			time.Sleep(backoff)
+			backoff = min(backoff*2, maxBackoff)
+			continue
+		}
+		backoff = minBackoff
+		if connected {
+			reconnectsTotal.Inc()
+			logger.FromContext(ctx).Warn("Cache invalidation channel reconnected, reset local cache")
+			h.onReset()
+		}
+		connected = true
+
+		for {
+			msg, err := ps.ReceiveMessage(ctx)
+			if err != nil {
+				logger.FromContext(ctx).Warn("Cache invalidation subscription broken", zap.Error(err))
+				break
+			}
+			handleMessage(ctx, msg.Payload, h)
+		}
+		ps.Close()
+	}
+}
+
+// handleMessage 解析消息，删除本地缓存或将新增商品加入布隆过滤器
+func handleMessage(ctx context.Context, payload string, h *handlers) {
+	var msg Message
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		logger.FromContext(ctx).Error("Failed to unmarshal cache invalidation message", zap.Error(err))
+		return
+	}
+	if len(msg.Keys) > 0 {
+		h.onEvict(msg.Keys)
+	}
+	if len(msg.NewGoods) > 0 && h.onNewGoods != nil {
+		h.onNewGoods(msg.NewGoods)
+	}
+	receivedTotal.Inc()
+	lag := time.Since(time.UnixMilli(msg.PublishedAt))
+	if lag < 0 {
+		// 实例间时钟偏差可能导致负数
+		lag = 0
+	}
+	invalidationLag.Observe(lag.Seconds())
+}
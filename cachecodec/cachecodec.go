@@ -0,0 +1,89 @@
+package cachecodec
+
+import (
+	"errors"
+	"goods_srv/metrics"
+	"goods_srv/proto"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// 缓存条目的编解码
+// 缓存数据使用 protobuf 编码后放入 CacheEnvelope 信封，信封中记录缓存结构版本、写入时间和数据库记录的版本号。
+// 缓存的 protobuf 消息发生不兼容的修改（删除或修改字段类型、语义）时，需要递增 SchemaVersion，
+// 旧版本写入的缓存在读取时会被直接丢弃并重新回源。
+
+const (
+	// SchemaVersion 当前写入缓存时使用的结构版本
+	// 2: GoodsDetail 新增 Version 字段，旧版本的缓存中没有版本号，不能再使用
+	SchemaVersion uint32 = 2
+
+	// MinSchemaVersion 读取时能兼容的最低结构版本
+	// 只新增可以缺省的字段时 protobuf 前后兼容，不需要修改这两个版本号
+	MinSchemaVersion uint32 = 2
+)
+
+var (
+	// ErrIncompatible 缓存条目由不兼容的结构版本写入
+	ErrIncompatible = errors.New("incompatible cache schema version")
+	// ErrCorrupted 缓存条目无法解析，例如旧版本写入的 JSON 数据
+	ErrCorrupted = errors.New("corrupted cache entry")
+
+	discardedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache_codec",
+		Name:      "discarded_total",
+		Help:      "读取时被丢弃的缓存条目数：incompatible 结构版本不兼容，corrupted 无法解析",
+	}, []string{"reason"})
+)
+
+func init() {
+	metrics.MustRegister(discardedTotal)
+}
+
+// Meta 缓存条目信封中的元信息
+type Meta struct {
+	SchemaVersion uint32    // 写入时的结构版本
+	WriteTime     time.Time // 写入时间
+	RowVersion    int64     // 数据库记录的版本号
+}
+
+// Marshal 将消息编码为带信封的缓存数据，rowVersion 为数据库记录的版本号
+func Marshal(msg gproto.Message, rowVersion int64) ([]byte, error) {
+	payload, err := gproto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return gproto.Marshal(&proto.CacheEnvelope{
+		SchemaVersion: SchemaVersion,
+		WriteTime:     time.Now().UnixMilli(),
+		RowVersion:    rowVersion,
+		Payload:       payload,
+	})
+}
+
+// Unmarshal 解析带信封的缓存数据到 msg 中，并返回信封中的元信息
+// 结构版本不兼容时返回 ErrIncompatible，数据无法解析时返回 ErrCorrupted，调用方应丢弃该缓存
+func Unmarshal(data []byte, msg gproto.Message) (Meta, error) {
+	var envelope proto.CacheEnvelope
+	if err := gproto.Unmarshal(data, &envelope); err != nil || envelope.SchemaVersion == 0 {
+		discardedTotal.WithLabelValues("corrupted").Inc()
+		return Meta{}, ErrCorrupted
+	}
+	meta := Meta{
+		SchemaVersion: envelope.SchemaVersion,
+		WriteTime:     time.UnixMilli(envelope.WriteTime),
+		RowVersion:    envelope.RowVersion,
+	}
+	if envelope.SchemaVersion < MinSchemaVersion || envelope.SchemaVersion > SchemaVersion {
+		discardedTotal.WithLabelValues("incompatible").Inc()
+		return meta, ErrIncompatible
+	}
+	if err := gproto.Unmarshal(envelope.Payload, msg); err != nil {
+		discardedTotal.WithLabelValues("corrupted").Inc()
+		return meta, ErrCorrupted
+	}
+	return meta, nil
+}
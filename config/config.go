@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -31,6 +32,15 @@ type SrvConfig struct {
 	*MySQLConfig  `mapstructure:"mysql"`
 	*RedisConfig  `mapstructure:"redis"`
 	*ConsulConfig `mapstructure:"consul"`
+
+	*BloomFilterConfig   `mapstructure:"bloom_filter"`
+	*CacheConfig         `mapstructure:"cache"`
+	*LocalCacheConfig    `mapstructure:"local_cache"`
+	*BinlogConfig        `mapstructure:"binlog"`
+	*HotKeyConfig        `mapstructure:"hot_key"`
+	*WarmUpConfig        `mapstructure:"warm_up"`
+	*PriceScheduleConfig `mapstructure:"price_schedule"`
+	*RoomWatchConfig     `mapstructure:"room_watch"`
 }
 
 type MySQLConfig struct {
@@ -64,6 +74,63 @@ type ConsulConfig struct {
 	Addr string `mapstructure:"addr"`
 }
 
+type BloomFilterConfig struct {
+	Type            string        `mapstructure:"type"`             // 过滤器实现：local（进程内存）/ redis（Redis 位图，实例间共享）
+	RebuildInterval time.Duration `mapstructure:"rebuild_interval"` // 全量重建间隔，例如 "6h"
+
+	ExpectedItems     uint    `mapstructure:"expected_items"`      // 预计商品总数，用于计算位数组大小
+	FalsePositiveRate float64 `mapstructure:"false_positive_rate"` // 期望误判率，例如 0.0001
+	SnapshotPath      string  `mapstructure:"snapshot_path"`       // 本地过滤器快照文件路径，为空则不使用快照
+}
+
+type CacheConfig struct {
+	TombstoneTTL time.Duration `mapstructure:"tombstone_ttl"` // 不存在商品的空值缓存过期时间，例如 "1m"
+
+	// 商品写操作后的缓存一致性策略：delete / delayed_double_delete / write_through / versioned
+	WriteStrategy     string        `mapstructure:"write_strategy"`
+	DoubleDeleteDelay time.Duration `mapstructure:"double_delete_delay"` // 延迟双删的间隔，例如 "1s"
+
+	// MySQL 或 Redis 故障时允许返回的旧数据的最长时间，例如 "30m"，0 表示不降级
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+}
+
+type LocalCacheConfig struct {
+	MaxEntries int           `mapstructure:"max_entries"` // 最大条目数
+	MaxBytes   int64         `mapstructure:"max_bytes"`   // 最大占用字节数，0 表示不限制
+	Policy     string        `mapstructure:"policy"`      // 淘汰策略：lru / lfu
+	TTL        time.Duration `mapstructure:"ttl"`         // 商品详情的本地缓存过期时间，例如 "10m"
+}
+
+type BinlogConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`   // 是否订阅 binlog 删除缓存
+	ServerID uint32 `mapstructure:"server_id"` // 以从库身份连接 MySQL 使用的 server_id，不能与其他从库重复
+}
+
+type HotKeyConfig struct {
+	Window       time.Duration `mapstructure:"window"`        // 滑动窗口大小，例如 "10s"
+	Buckets      int           `mapstructure:"buckets"`       // 窗口划分的桶数
+	Threshold    uint64        `mapstructure:"threshold"`     // 窗口内请求数达到该值即为热点
+	MaxKeys      int           `mapstructure:"max_keys"`      // 最多固定在本地缓存中的热点 key 数
+	RefreshAhead time.Duration `mapstructure:"refresh_ahead"` // 本地缓存剩余有效期小于该值时提前刷新，例如 "1m"
+}
+
+type WarmUpConfig struct {
+	OnBoot      bool          `mapstructure:"on_boot"`     // 启动时是否预热正在直播的直播间商品
+	Concurrency int           `mapstructure:"concurrency"` // 同时回源的批次数
+	BatchSize   int           `mapstructure:"batch_size"`  // 每批预热的商品数
+	Timeout     time.Duration `mapstructure:"timeout"`     // 启动预热的最长时间，例如 "30s"
+}
+
+type PriceScheduleConfig struct {
+	Interval  time.Duration `mapstructure:"interval"`   // 检查到期定时改价的间隔，例如 "5s"
+	BatchSize int           `mapstructure:"batch_size"` // 每次最多处理的定时改价数
+}
+
+type RoomWatchConfig struct {
+	BufferSize   int   `mapstructure:"buffer_size"`    // 每个订阅者缓冲的事件数，缓冲区满时重新发送快照
+	StreamMaxLen int64 `mapstructure:"stream_max_len"` // 每个直播间保留的事件数，用于断线重连后补发
+}
+
 // Init 整个服务配置文件初始化的方法
 func Init(filePath string) (err error) {
 	// 方式1：直接指定配置文件路径（相对路径或者绝对路径）
@@ -0,0 +1,75 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"goods_srv/errno"
+	"goods_srv/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// createChangeLog 在事务中写入一条商品修改日志，oldValues 和 newValues 以 JSON 保存
+func createChangeLog(tx *gorm.DB, goodsId int64, action string, oldValues, newValues map[string]interface{}, change ChangeInfo, now time.Time) error {
+	changeLog := &model.GoodsChangeLog{
+		GoodsId:    goodsId,
+		Action:     action,
+		Operator:   change.Operator,
+		Reason:     change.Reason,
+		ScheduleId: change.scheduleId,
+		CreateAt:   now,
+	}
+	if oldValues != nil {
+		data, err := json.Marshal(oldValues)
+		if err != nil {
+			return err
+		}
+		changeLog.OldValue = string(data)
+	}
+	if newValues != nil {
+		data, err := json.Marshal(newValues)
+		if err != nil {
+			return err
+		}
+		changeLog.NewValue = string(data)
+	}
+	return tx.Create(changeLog).Error
+}
+
+// GetGoodsChangeLogs 分页查询商品的修改日志，按修改时间倒序返回，同时返回日志总数
+func GetGoodsChangeLogs(ctx context.Context, goodsId int64, page, pageSize int) ([]*model.GoodsChangeLog, int64, error) {
+	query := db.WithContext(ctx).
+		Model(&model.GoodsChangeLog{}).
+		Where("goods_id = ?", goodsId)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errno.ErrQueryFailed
+	}
+
+	var data []*model.GoodsChangeLog
+	err := query.
+		Order("id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&data).Error
+	if err != nil {
+		return nil, 0, errno.ErrQueryFailed
+	}
+	return data, total, nil
+}
+
+// GetPriceChangeLogs 查询修改过售价的商品修改日志，按修改时间排序
+func GetPriceChangeLogs(ctx context.Context, goodsId int64) ([]*model.GoodsChangeLog, error) {
+	var data []*model.GoodsChangeLog
+	err := db.WithContext(ctx).
+		Model(&model.GoodsChangeLog{}).
+		Where("goods_id = ? AND new_value LIKE ?", goodsId, `%"price":%`).
+		Order("id").
+		Find(&data).Error
+	if err != nil {
+		return nil, errno.ErrQueryFailed
+	}
+	return data, nil
+}
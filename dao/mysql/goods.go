@@ -2,16 +2,27 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"goods_srv/errno"
+	"goods_srv/logger"
 	"goods_srv/model"
-	"log"
+	"time"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 // dao 层用来执行数据库相关的操作
 
+// mysqlErrDupEntry 违反唯一索引时 MySQL 返回的错误码
+const mysqlErrDupEntry = 1062
+
+// nextVersion 递增版本号的表达式，版本号列为 SMALLINT UNSIGNED，达到 65535 后回绕到 0，
+// 直接 version + 1 会超出范围导致之后无法再更新。缓存按回绕后的序号比较新旧（见 biz/goods/consistency.go）
+var nextVersion = gorm.Expr("CASE WHEN version = 65535 THEN 0 ELSE version + 1 END")
+
 // GetGoodsByRoomId 根据roomID查询直播间绑定的所有商品信息
 func GetGoodsByRoomId(ctx context.Context, roomId int64) ([]*model.RoomGoods, error) {
 	// 定义一个切片变量 data，用于存储查询结果
@@ -23,8 +34,8 @@ func GetGoodsByRoomId(ctx context.Context, roomId int64) ([]*model.RoomGoods, er
 	err := db.WithContext(ctx).
 		// 指定操作的模型，这里操作的是 model.RoomGoods 表
 		Model(&model.RoomGoods{}).
-		// 添加查询条件，过滤出 room_id 等于传入的 roomId 的记录
-		Where("room_id = ?", roomId).
+		// 添加查询条件，过滤出 room_id 等于传入的 roomId 且未删除的记录
+		Where("room_id = ? AND is_del = 0", roomId).
 		// 按照权重字段（weight）排序，确保返回的结果有序
 		Order("weight").
 		// 执行查询操作，将结果存储到 data 中
@@ -51,8 +62,8 @@ func GetGoodsByIdList(ctx context.Context, idList []int64) ([]*model.Goods, erro
 	err := db.WithContext(ctx).
 		// 指定操作的模型，这里操作的是 model.Goods 表
 		Model(&model.Goods{}).
-		// 添加查询条件，过滤出 goods_id 在 idList 中的记录
-		Where("goods_id in ?", idList).
+		// 添加查询条件，过滤出 goods_id 在 idList 中且未删除的记录
+		Where("goods_id in ? AND is_del = 0", idList).
 		// 使用 Clauses 方法添加自定义的排序逻辑
 		// 确保查询结果按照 idList 中的顺序返回
 		Clauses(clause.OrderBy{
@@ -87,11 +98,16 @@ func GetGoodsDetailById(ctx context.Context, goodsId int64) (*model.Goods, error
 	err := db.WithContext(ctx).
 		// 指定操作的模型，这里操作的是 model.Goods 表
 		Model(&model.Goods{}).
-		// 添加查询条件，过滤出 goods_id 在 idList 中的记录
-		Where("goods_id = ?", goodsId).
+		// 添加查询条件，过滤出 goods_id 等于传入的 goodsId 且未删除的记录
+		Where("goods_id = ? AND is_del = 0", goodsId).
 		// 执行查询操作，将结果存储到 data 中
 		First(data).Error
 
+	// 商品不存在时返回 nil，由上层区分"不存在"和"查询失败"
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
 	// 如果查询出错且不是空数据的错误
 	if err != nil && err != gorm.ErrEmptySlice {
 		// 返回一个自定义的错误，表示查询失败
@@ -102,33 +118,26 @@ func GetGoodsDetailById(ctx context.Context, goodsId int64) (*model.Goods, error
 	return data, nil
 }
 
-func UpdateGoodsDetail(ctx context.Context, goodsId int64, newPrice int64) error {
+// UpdateGoodsDetail 更新商品价格，expectedVersion 不为 nil 时只有版本号一致才更新（乐观锁）
+func UpdateGoodsDetail(ctx context.Context, goodsId int64, newPrice int64, expectedVersion *uint16, change ChangeInfo) error {
+	// 只更新 price 字段，同时递增版本号，供缓存按版本号判断新旧
+	return UpdateGoodsFields(ctx, goodsId, map[string]interface{}{"price": newPrice}, expectedVersion, change)
+}
 
-	// 使用 gorm 的 WithContext 方法，将上下文传递给数据库操作
-	result := db.WithContext(ctx).
-		// 指定操作的模型，这里操作的是 model.Goods 表
-		Model(&model.Goods{}).
-		// 指定更新条件，根据 goods_id 更新
-		Where("goods_id = ?", goodsId).
-		// 只更新 price 字段
-		Updates(map[string]interface{}{
-			"price": newPrice,
-		})
-
-	// 检查更新是否成功
-	if result.Error != nil {
-		log.Printf("Failed to update goods detail: %v", result.Error)
-		return errno.ErrUpdateFailed
-	}
+// GetActiveRoomIds 查询正在直播的直播间 ID，即有正在讲解的商品的直播间
+func GetActiveRoomIds(ctx context.Context) ([]int64, error) {
+	var roomIds []int64
 
-	// 如果没有行被更新，返回错误
-	if result.RowsAffected == 0 {
-		log.Printf("No rows affected for goodsId: %d", goodsId)
-		return errno.ErrGoodsDetailNotFound
+	err := db.WithContext(ctx).
+		Model(&model.RoomGoods{}).
+		Distinct("room_id").
+		Where("is_current = ? AND is_del = 0", 1).
+		Find(&roomIds).Error
+	if err != nil {
+		return nil, errno.ErrQueryFailed
 	}
 
-	// 更新成功，返回 nil
-	return nil
+	return roomIds, nil
 }
 
 // GetAllGoodsIDs 查询数据库中所有商品的 ID
@@ -139,8 +148,9 @@ func GetAllGoodsIDs(ctx context.Context) ([]int64, error) {
 	err := db.WithContext(ctx).
 		// 指定操作的模型，这里操作的是 model.Goods 表
 		Model(&model.Goods{}).
-		// 选择只查询 goods_id 字段
+		// 选择只查询 goods_id 字段，已删除的商品不加入布隆过滤器
 		Select("goods_id").
+		Where("is_del = 0").
 		// 执行查询操作，将结果存储到 goodsIDs 中
 		Find(&goodsIDs).Error
 
@@ -151,3 +161,278 @@ func GetAllGoodsIDs(ctx context.Context) ([]int64, error) {
 
 	return goodsIDs, nil
 }
+
+// GetMaxGoodsPK 查询商品表当前最大的主键ID
+func GetMaxGoodsPK(ctx context.Context) (uint, error) {
+	var maxPK uint
+
+	err := db.WithContext(ctx).
+		Model(&model.Goods{}).
+		// 表为空时 MAX 返回 NULL，使用 COALESCE 兜底为 0
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&maxPK).Error
+	if err != nil {
+		return 0, errno.ErrQueryFailed
+	}
+
+	return maxPK, nil
+}
+
+// GetGoodsIDsAfter 查询主键大于 lastPK 的商品（只包含 id 和 goods_id），用于增量加载
+func GetGoodsIDsAfter(ctx context.Context, lastPK uint) ([]*model.Goods, error) {
+	var data []*model.Goods
+
+	err := db.WithContext(ctx).
+		Model(&model.Goods{}).
+		Select("id", "goods_id").
+		Where("id > ? AND is_del = 0", lastPK).
+		Order("id").
+		Find(&data).Error
+	if err != nil {
+		return nil, errno.ErrQueryFailed
+	}
+
+	return data, nil
+}
+
+// ChangeInfo 商品写操作的审计信息
+type ChangeInfo struct {
+	Operator string // 操作人
+	Reason   string // 修改原因
+
+	scheduleId uint // 定时改价 ID，只由定时改价的生效和恢复设置
+}
+
+// CreateGoods 新增商品并记录修改日志，商品 ID 或商品编码已被使用时返回 ErrGoodsAlreadyExist
+// 已软删除的商品仍然占用商品 ID 和商品编码
+func CreateGoods(ctx context.Context, goods *model.Goods, change ChangeInfo) error {
+	var count int64
+	err := db.WithContext(ctx).
+		Model(&model.Goods{}).
+		Where("goods_id = ? OR code = ?", goods.GoodsId, goods.Code).
+		Count(&count).Error
+	if err != nil {
+		return errno.ErrQueryFailed
+	}
+	if count > 0 {
+		return errno.ErrGoodsAlreadyExist
+	}
+
+	// 填充审计字段
+	now := time.Now()
+	goods.CreateAt = now
+	goods.UpdateAt = now
+	goods.CreateBy = change.Operator
+	goods.UpdateBy = change.Operator
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(goods).Error; err != nil {
+			return err
+		}
+		return createChangeLog(tx, goods.GoodsId, model.ChangeActionCreate, nil, goodsColumns(goods), change, now)
+	})
+	if err != nil {
+		// 并发创建时由唯一索引兜底
+		if isDupEntry(err) {
+			return errno.ErrGoodsAlreadyExist
+		}
+		logger.FromContext(ctx).Error("Failed to create goods", zap.Error(err))
+		return errno.ErrCreateFailed
+	}
+	return nil
+}
+
+// DeleteGoods 软删除商品并记录修改日志，同时递增版本号
+func DeleteGoods(ctx context.Context, goodsId int64, change ChangeInfo) error {
+	fields := map[string]interface{}{"is_del": 1, "status": model.GoodsStatusDeleted}
+	err := updateGoods(ctx, goodsId, fields, nil, model.ChangeActionDelete, change)
+	if err != nil && !errors.Is(err, errno.ErrGoodsDetailNotFound) {
+		logger.FromContext(ctx).Error("Failed to delete goods", zap.Error(err))
+		return errno.ErrDeleteFailed
+	}
+	return err
+}
+
+// ChangeGoodsStatus 按允许的状态流转修改商品状态并记录修改日志，返回修改后的商品和修改前的状态
+// 当前状态不允许变更为目标状态时返回 StatusTransitionError，变更为已删除时同时软删除商品
+func ChangeGoodsStatus(ctx context.Context, goodsId int64, to int8, change ChangeInfo) (*model.Goods, int8, error) {
+	var (
+		updated model.Goods
+		from    int8
+	)
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockGoods(tx, goodsId)
+		if err != nil {
+			return err
+		}
+		if !model.CanChangeGoodsStatus(current.Status, to) {
+			return &errno.StatusTransitionError{From: current.Status, To: to}
+		}
+
+		fields := map[string]interface{}{"status": to}
+		action := model.ChangeActionUpdate
+		if to == model.GoodsStatusDeleted {
+			fields["is_del"] = 1
+			action = model.ChangeActionDelete
+		}
+		if _, err := updateGoodsTx(tx, goodsId, fields, nil, action, change); err != nil {
+			return err
+		}
+		from = current.Status
+		updated = *current
+		updated.Status = to
+		updated.Version++
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errno.ErrGoodsDetailNotFound) || errors.Is(err, errno.ErrStatusTransition) {
+			return nil, 0, err
+		}
+		logger.FromContext(ctx).Error("Failed to change goods status", zap.Error(err))
+		return nil, 0, errno.ErrUpdateFailed
+	}
+	return &updated, from, nil
+}
+
+// UpdateGoodsFields 更新商品的指定字段并记录修改日志，fields 为列名到新值的映射，同时递增版本号
+// expectedVersion 不为 nil 时只有版本号一致才更新（乐观锁），不一致时返回 VersionConflictError
+func UpdateGoodsFields(ctx context.Context, goodsId int64, fields map[string]interface{}, expectedVersion *uint16, change ChangeInfo) error {
+	err := updateGoods(ctx, goodsId, fields, expectedVersion, model.ChangeActionUpdate, change)
+	switch {
+	case err == nil, errors.Is(err, errno.ErrGoodsDetailNotFound), errors.Is(err, errno.ErrVersionConflict):
+		return err
+	case isDupEntry(err):
+		// 修改商品编码时可能与其他商品重复
+		return errno.ErrGoodsAlreadyExist
+	default:
+		logger.FromContext(ctx).Error("Failed to update goods fields", zap.Error(err))
+		return errno.ErrUpdateFailed
+	}
+}
+
+// updateGoods 在事务中锁定商品、校验版本号、更新字段并记录修改前后的值
+func updateGoods(ctx context.Context, goodsId int64, fields map[string]interface{}, expectedVersion *uint16, action string, change ChangeInfo) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := updateGoodsTx(tx, goodsId, fields, expectedVersion, action, change)
+		return err
+	})
+}
+
+// updateGoodsTx 在调用方的事务中更新商品，返回修改前的商品
+func updateGoodsTx(tx *gorm.DB, goodsId int64, fields map[string]interface{}, expectedVersion *uint16, action string, change ChangeInfo) (*model.Goods, error) {
+	// 1. 锁定当前记录，读取修改前的值和版本号
+	current, err := lockGoods(tx, goodsId)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != nil && *expectedVersion != current.Version {
+		return nil, &errno.VersionConflictError{Current: current.Version}
+	}
+
+	// 2. 更新字段，同时递增版本号并填充审计字段
+	now := time.Now()
+	updates := make(map[string]interface{}, len(fields)+3)
+	for column, value := range fields {
+		updates[column] = value
+	}
+	updates["version"] = nextVersion
+	updates["update_at"] = now
+	updates["update_by"] = change.Operator
+	err = tx.Model(&model.Goods{}).
+		Where("id = ?", current.ID).
+		Updates(updates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 记录修改日志
+	columns := goodsColumns(current)
+	oldValues := make(map[string]interface{}, len(fields))
+	for column := range fields {
+		oldValues[column] = columns[column]
+	}
+	if err := createChangeLog(tx, goodsId, action, oldValues, fields, change, now); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// lockGoods 在事务中锁定未删除的商品（SELECT ... FOR UPDATE），商品不存在时返回 ErrGoodsDetailNotFound
+func lockGoods(tx *gorm.DB, goodsId int64) (*model.Goods, error) {
+	var current model.Goods
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("goods_id = ? AND is_del = 0", goodsId).
+		Take(&current).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrGoodsDetailNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &current, nil
+}
+
+// goodsColumns 返回商品业务字段的列名到值的映射，用于记录修改日志
+func goodsColumns(goods *model.Goods) map[string]interface{} {
+	return map[string]interface{}{
+		"category_id":  goods.CategoryId,
+		"brand_name":   goods.BrandName,
+		"code":         goods.Code,
+		"status":       goods.Status,
+		"title":        goods.Title,
+		"market_price": goods.MarketPrice,
+		"price":        goods.Price,
+		"brief":        goods.Brief,
+		"is_del":       goods.IsDel,
+	}
+}
+
+// isDupEntry 判断是否为违反唯一索引的错误
+func isDupEntry(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDupEntry
+}
+
+// GoodsFilter 商品列表的查询条件，零值表示不过滤
+type GoodsFilter struct {
+	CategoryId int64  // 商品分类 ID
+	BrandName  string // 品牌名称
+	Status     *int8  // 商品状态，nil 表示不过滤
+	Keyword    string // 商品标题关键字
+	Page       int    // 页码，从 1 开始
+	PageSize   int    // 每页条数
+}
+
+// ListGoods 按条件分页查询未删除的商品，返回当前页的商品和符合条件的总数
+func ListGoods(ctx context.Context, filter *GoodsFilter) ([]*model.Goods, int64, error) {
+	query := db.WithContext(ctx).
+		Model(&model.Goods{}).
+		Where("is_del = 0")
+	if filter.CategoryId > 0 {
+		query = query.Where("category_id = ?", filter.CategoryId)
+	}
+	if filter.BrandName != "" {
+		query = query.Where("brand_name = ?", filter.BrandName)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.Keyword != "" {
+		query = query.Where("title LIKE ?", "%"+filter.Keyword+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errno.ErrQueryFailed
+	}
+
+	var data []*model.Goods
+	err := query.
+		Order("id").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&data).Error
+	if err != nil {
+		return nil, 0, errno.ErrQueryFailed
+	}
+	return data, total, nil
+}
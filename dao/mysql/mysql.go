@@ -39,3 +39,8 @@ func Init(cfg *config.MySQLConfig) (err error) {
 	sqlDB.SetConnMaxLifetime(time.Hour)
 	return
 }
+
+// InitWithDB 使用已经建立的连接初始化，测试中传入内存数据库
+func InitWithDB(gdb *gorm.DB) {
+	db = gdb
+}
@@ -0,0 +1,146 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/model"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// CreatePriceSchedule 新增定时改价，同一商品生效时间段重叠的定时改价返回 ErrPriceScheduleOverlap
+func CreatePriceSchedule(ctx context.Context, schedule *model.PriceSchedule) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁定商品，同一商品的定时改价串行创建，避免并发创建出重叠的时间段
+		if _, err := lockGoods(tx, schedule.GoodsId); err != nil {
+			return err
+		}
+
+		// 检查是否与待生效或生效中的定时改价重叠
+		// 没有结束时间的定时改价生效后即结束，之前版本遗留的生效中记录同样不参与检查
+		query := tx.Model(&model.PriceSchedule{}).
+			Where("goods_id = ?", schedule.GoodsId).
+			Where("status = ? OR (status = ? AND effective_to IS NOT NULL)",
+				model.PriceSchedulePending, model.PriceScheduleActive).
+			Where("effective_to IS NULL OR effective_to > ?", schedule.EffectiveFrom)
+		if schedule.EffectiveTo != nil {
+			query = query.Where("effective_from < ?", *schedule.EffectiveTo)
+		}
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errno.ErrPriceScheduleOverlap
+		}
+
+		now := time.Now()
+		schedule.Status = model.PriceSchedulePending
+		schedule.CreateAt = now
+		schedule.UpdateAt = now
+		return tx.Create(schedule).Error
+	})
+	if err != nil && !errors.Is(err, errno.ErrGoodsDetailNotFound) && !errors.Is(err, errno.ErrPriceScheduleOverlap) {
+		logger.FromContext(ctx).Error("Failed to create price schedule", zap.Error(err))
+		return errno.ErrCreateFailed
+	}
+	return err
+}
+
+// GetDuePriceSchedules 查询到期需要处理的定时改价：到达生效时间的待生效记录和到达结束时间的生效中记录
+func GetDuePriceSchedules(ctx context.Context, now time.Time, limit int) ([]*model.PriceSchedule, error) {
+	var data []*model.PriceSchedule
+	err := db.WithContext(ctx).
+		Model(&model.PriceSchedule{}).
+		Where("(status = ? AND effective_from <= ?) OR (status = ? AND effective_to <= ?)",
+			model.PriceSchedulePending, now, model.PriceScheduleActive, now).
+		Order("id").
+		Limit(limit).
+		Find(&data).Error
+	if err != nil {
+		return nil, errno.ErrQueryFailed
+	}
+	return data, nil
+}
+
+// ApplyPriceSchedule 在一个事务中将定时改价应用到商品，记录原价并将状态改为生效中
+// 没有结束时间的定时改价不需要恢复原价，生效后直接改为已结束
+// 其他实例已处理过该记录时返回 ErrPriceScheduleHandled
+func ApplyPriceSchedule(ctx context.Context, schedule *model.PriceSchedule, change ChangeInfo) error {
+	change.scheduleId = schedule.ID
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := updateGoodsTx(tx, schedule.GoodsId, map[string]interface{}{"price": schedule.Price}, nil, model.ChangeActionUpdate, change)
+		if err != nil {
+			return err
+		}
+		status := model.PriceScheduleActive
+		if schedule.EffectiveTo == nil {
+			status = model.PriceScheduleFinished
+		}
+		return updatePriceScheduleStatus(tx, schedule.ID, model.PriceSchedulePending, map[string]interface{}{
+			"status":         status,
+			"original_price": current.Price,
+		})
+	})
+}
+
+// RevertPriceSchedule 在一个事务中结束定时改价，恢复原价并将状态改为已结束
+// 生效期间价格被手动修改过时不再恢复，只结束定时改价
+func RevertPriceSchedule(ctx context.Context, schedule *model.PriceSchedule, change ChangeInfo) error {
+	change.scheduleId = schedule.ID
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := lockGoods(tx, schedule.GoodsId)
+		if err != nil && !errors.Is(err, errno.ErrGoodsDetailNotFound) {
+			return err
+		}
+		if current != nil && current.Price == schedule.Price {
+			_, err := updateGoodsTx(tx, schedule.GoodsId, map[string]interface{}{"price": schedule.OriginalPrice}, nil, model.ChangeActionUpdate, change)
+			if err != nil {
+				return err
+			}
+		}
+		return updatePriceScheduleStatus(tx, schedule.ID, model.PriceScheduleActive, map[string]interface{}{
+			"status": model.PriceScheduleFinished,
+		})
+	})
+}
+
+// CancelPriceSchedule 取消待生效的定时改价，例如商品已被删除
+func CancelPriceSchedule(ctx context.Context, scheduleId uint) error {
+	return updatePriceScheduleStatus(db.WithContext(ctx), scheduleId, model.PriceSchedulePending, map[string]interface{}{
+		"status": model.PriceScheduleCanceled,
+	})
+}
+
+// updatePriceScheduleStatus 只有当前状态为 from 时才更新，保证多个实例中只有一个能处理同一条记录
+func updatePriceScheduleStatus(tx *gorm.DB, scheduleId uint, from int8, updates map[string]interface{}) error {
+	updates["update_at"] = time.Now()
+	result := tx.Model(&model.PriceSchedule{}).
+		Where("id = ? AND status = ?", scheduleId, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errno.ErrPriceScheduleHandled
+	}
+	return nil
+}
+
+// GetPriceSchedules 查询商品的所有定时改价，按生效时间排序
+func GetPriceSchedules(ctx context.Context, goodsId int64) ([]*model.PriceSchedule, error) {
+	var data []*model.PriceSchedule
+	err := db.WithContext(ctx).
+		Model(&model.PriceSchedule{}).
+		Where("goods_id = ?", goodsId).
+		Order("effective_from").
+		Find(&data).Error
+	if err != nil {
+		return nil, errno.ErrQueryFailed
+	}
+	return data, nil
+}
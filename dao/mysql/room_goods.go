@@ -0,0 +1,215 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/model"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 直播间商品管理：绑定、解绑、排序和切换当前讲解的商品
+
+// reorderWeightStep 重新排序时相邻商品的权重间隔，留出空间方便之后插入
+const reorderWeightStep = 10
+
+// BindGoodsToRoom 将商品绑定到直播间，之前解绑过的商品重新绑定
+func BindGoodsToRoom(ctx context.Context, roomId, goodsId, weight int64, operator string) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 商品必须存在且未删除
+		var count int64
+		err := tx.Model(&model.Goods{}).
+			Where("goods_id = ? AND is_del = 0", goodsId).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errno.ErrGoodsDetailNotFound
+		}
+
+		// 查询已有的绑定关系（包括已解绑的）
+		var binding model.RoomGoods
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("room_id = ? AND goods_id = ?", roomId, goodsId).
+			Take(&binding).Error
+		now := time.Now()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			binding = model.RoomGoods{
+				BaseModel: model.BaseModel{CreateAt: now, UpdateAt: now, CreateBy: operator, UpdateBy: operator},
+				RoomId:    roomId,
+				GoodsId:   goodsId,
+				Weight:    weight,
+			}
+			return tx.Create(&binding).Error
+		}
+		if err != nil {
+			return err
+		}
+		if binding.IsDel == 0 {
+			return errno.ErrGoodsAlreadyBound
+		}
+		return tx.Model(&model.RoomGoods{}).
+			Where("id = ?", binding.ID).
+			Updates(map[string]interface{}{
+				"is_del":     0,
+				"is_current": 0,
+				"weight":     weight,
+				"version":    nextVersion,
+				"update_at":  now,
+				"update_by":  operator,
+			}).Error
+	})
+	return roomGoodsError(ctx, err)
+}
+
+// UnbindGoodsFromRoom 解绑直播间的商品（软删除），正在讲解的商品解绑后直播间没有当前讲解的商品
+func UnbindGoodsFromRoom(ctx context.Context, roomId, goodsId int64, operator string) error {
+	result := db.WithContext(ctx).
+		Model(&model.RoomGoods{}).
+		Where("room_id = ? AND goods_id = ? AND is_del = 0", roomId, goodsId).
+		Updates(map[string]interface{}{
+			"is_del":     1,
+			"is_current": 0,
+			"version":    nextVersion,
+			"update_at":  time.Now(),
+			"update_by":  operator,
+		})
+	if result.Error != nil {
+		return roomGoodsError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errno.ErrRoomGoodsNotFound
+	}
+	return nil
+}
+
+// ReorderRoomGoods 按 goodsIds 的顺序重新设置直播间商品的权重
+// goodsIds 必须与直播间当前绑定的商品完全一致，否则返回 ErrRoomGoodsMismatch
+func ReorderRoomGoods(ctx context.Context, roomId int64, goodsIds []int64, operator string) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bindings, err := lockRoomGoods(tx, roomId)
+		if err != nil {
+			return err
+		}
+		if len(bindings) != len(goodsIds) {
+			return errno.ErrRoomGoodsMismatch
+		}
+		bound := make(map[int64]bool, len(bindings))
+		for _, binding := range bindings {
+			bound[binding.GoodsId] = true
+		}
+		for _, goodsId := range goodsIds {
+			if !bound[goodsId] {
+				return errno.ErrRoomGoodsMismatch
+			}
+			delete(bound, goodsId) // 重复的商品 ID 第二次会查不到
+		}
+
+		now := time.Now()
+		for i, goodsId := range goodsIds {
+			err := tx.Model(&model.RoomGoods{}).
+				Where("room_id = ? AND goods_id = ? AND is_del = 0", roomId, goodsId).
+				Updates(map[string]interface{}{
+					"weight":    (i + 1) * reorderWeightStep,
+					"version":   nextVersion,
+					"update_at": now,
+					"update_by": operator,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return roomGoodsError(ctx, err)
+}
+
+// SetCurrentGoods 切换直播间当前讲解的商品，在一个事务中保证直播间最多只有一个 is_current = 1 的商品
+// goodsId 为 0 表示清除当前讲解的商品
+func SetCurrentGoods(ctx context.Context, roomId, goodsId int64, operator string) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁定直播间的所有商品，并发切换时串行执行
+		bindings, err := lockRoomGoods(tx, roomId)
+		if err != nil {
+			return err
+		}
+		found := goodsId == 0
+		for _, binding := range bindings {
+			if binding.GoodsId == goodsId {
+				found = true
+			}
+		}
+		if !found {
+			return errno.ErrRoomGoodsNotFound
+		}
+
+		now := time.Now()
+		err = tx.Model(&model.RoomGoods{}).
+			Where("room_id = ? AND is_del = 0 AND is_current = 1 AND goods_id <> ?", roomId, goodsId).
+			Updates(map[string]interface{}{
+				"is_current": 0,
+				"version":    nextVersion,
+				"update_at":  now,
+				"update_by":  operator,
+			}).Error
+		if err != nil || goodsId == 0 {
+			return err
+		}
+		return tx.Model(&model.RoomGoods{}).
+			Where("room_id = ? AND goods_id = ? AND is_del = 0", roomId, goodsId).
+			Updates(map[string]interface{}{
+				"is_current": 1,
+				"version":    nextVersion,
+				"update_at":  now,
+				"update_by":  operator,
+			}).Error
+	})
+	return roomGoodsError(ctx, err)
+}
+
+// GetRoomIdsByGoodsId 查询绑定了该商品的所有直播间 ID
+func GetRoomIdsByGoodsId(ctx context.Context, goodsId int64) ([]int64, error) {
+	var roomIds []int64
+	err := db.WithContext(ctx).
+		Model(&model.RoomGoods{}).
+		Where("goods_id = ? AND is_del = 0", goodsId).
+		Distinct().
+		Pluck("room_id", &roomIds).Error
+	if err != nil {
+		return nil, errno.ErrQueryFailed
+	}
+	return roomIds, nil
+}
+
+// lockRoomGoods 在事务中锁定直播间绑定的所有商品
+func lockRoomGoods(tx *gorm.DB, roomId int64) ([]*model.RoomGoods, error) {
+	var data []*model.RoomGoods
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("room_id = ? AND is_del = 0", roomId).
+		Find(&data).Error
+	return data, err
+}
+
+// roomGoodsError 保留业务错误，其他数据库错误统一转换为 ErrUpdateFailed
+func roomGoodsError(ctx context.Context, err error) error {
+	switch {
+	case err == nil,
+		errors.Is(err, errno.ErrGoodsDetailNotFound),
+		errors.Is(err, errno.ErrGoodsAlreadyBound),
+		errors.Is(err, errno.ErrRoomGoodsNotFound),
+		errors.Is(err, errno.ErrRoomGoodsMismatch):
+		return err
+	case isDupEntry(err):
+		// 并发绑定同一商品时由唯一索引兜底
+		return errno.ErrGoodsAlreadyBound
+	default:
+		logger.FromContext(ctx).Error("Failed to update room goods", zap.Error(err))
+		return errno.ErrUpdateFailed
+	}
+}
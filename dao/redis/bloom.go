@@ -0,0 +1,69 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 布隆过滤器位图相关的 Redis 操作
+// 位图使用 Redis 的 bit 操作，偏移量 offset 对应第 offset/8 个字节的第 7-offset%8 位
+
+// SetBits 将 key 对应位图中 offsets 位置为 1
+func SetBits(ctx context.Context, key string, offsets []uint64) error {
+	pipe := GetClient().Pipeline()
+	for _, offset := range offsets {
+		pipe.SetBit(ctx, key, int64(offset), 1)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TestBits 判断 key 对应位图中 offsets 位是否全部为 1
+func TestBits(ctx context.Context, key string, offsets []uint64) (bool, error) {
+	pipe := GetClient().Pipeline()
+	cmds := make([]interface{ Val() int64 }, 0, len(offsets))
+	for _, offset := range offsets {
+		cmds = append(cmds, pipe.GetBit(ctx, key, int64(offset)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	for _, cmd := range cmds {
+		if cmd.Val() == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// MergeBitmap 将整块位图数据按位或合并到 key 中
+// 先写入临时 key 再 BITOP OR，避免覆盖合并期间通过 SetBits 写入的位
+func MergeBitmap(ctx context.Context, key string, bitmap []byte, ttl time.Duration) error {
+	tmpKey := key + "_tmp"
+	if err := GetClient().Set(ctx, tmpKey, bitmap, ttl).Err(); err != nil {
+		return err
+	}
+	defer GetClient().Del(ctx, tmpKey)
+	return GetClient().BitOpOr(ctx, key, key, tmpKey).Err()
+}
+
+// CountBits 统计 key 对应位图中为 1 的位数
+func CountBits(ctx context.Context, key string) (int64, error) {
+	return GetClient().BitCount(ctx, key, nil).Result()
+}
+
+// setMaxScript 仅当新值大于 key 当前的值时写入，多个实例并发写入时 key 的值只增不减
+var setMaxScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[1]) > current then
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// SetMax 将 key 的值更新为 value 和当前值中较大的一个
+func SetMax(ctx context.Context, key string, value uint64) error {
+	return setMaxScript.Run(ctx, GetClient(), []string{key}, value).Err()
+}
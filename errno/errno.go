@@ -1,12 +1,103 @@
 package errno
 
-import "errors"
+import "fmt"
+
+// Error 业务错误，Code 为业务错误码，Reason 为机器可读的错误原因，返回给客户端时放在 google.rpc.ErrorInfo 中
+type Error struct {
+	Code    int    // 业务错误码，按模块分段
+	Reason  string // 错误原因，大写下划线格式，例如 GOODS_NOT_FOUND
+	Message string // 错误描述，用于日志
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// New 创建业务错误
+func New(code int, reason, message string) *Error {
+	return &Error{Code: code, Reason: reason, Message: message}
+}
 
 var (
-	ErrQueryFailed       = errors.New("query db failed")
-	ErrGoodsDetailNull   = errors.New("query goodsdetail null")
-	ErrUpdateFailed      = errors.New("update goodsdetail failed")
-	ErrCacheDeleteFailed = errors.New("delete cache failed")
-	ErrGoodsDetailNotFound = errors.New("found goodsdetail failed")
-	ErrGetLockFailed = errors.New("get lock failed")
+	// 100xx 存储和缓存
+	ErrQueryFailed       = New(10001, "QUERY_FAILED", "query db failed")
+	ErrUpdateFailed      = New(10002, "UPDATE_FAILED", "update goodsdetail failed")
+	ErrCreateFailed      = New(10003, "CREATE_FAILED", "create goods failed")
+	ErrDeleteFailed      = New(10004, "DELETE_FAILED", "delete goods failed")
+	ErrCacheDeleteFailed = New(10005, "CACHE_DELETE_FAILED", "delete cache failed")
+	ErrGetLockFailed     = New(10006, "GET_LOCK_FAILED", "get lock failed") // 获取回源分布式锁超时
+
+	// 200xx 商品
+	ErrGoodsDetailNull     = New(20001, "GOODS_DETAIL_NULL", "query goodsdetail null")
+	ErrGoodsDetailNotFound = New(20002, "GOODS_NOT_FOUND", "found goodsdetail failed")
+	ErrGoodsNotExist       = New(20003, "GOODS_NOT_EXIST", "goods not exist")             // 布隆过滤器判定商品一定不存在
+	ErrGoodsAlreadyExist   = New(20004, "GOODS_ALREADY_EXIST", "goods already exist")     // 商品 ID 或商品编码已被使用
+	ErrVersionConflict     = New(20005, "VERSION_CONFLICT", "version conflict")           // 期望的版本号与数据库中的版本号不一致
+	ErrStatusTransition    = New(20006, "STATUS_TRANSITION", "invalid status transition") // 商品当前状态不允许变更为目标状态
+	ErrInvalidUpdateMask   = New(20007, "INVALID_UPDATE_MASK", "invalid update mask")     // 字段掩码为空或包含不支持更新的字段
+	ErrInvalidField        = New(20008, "INVALID_FIELD", "invalid field value")           // 要更新的字段值不合法
+
+	// 300xx 定时改价
+	ErrPriceScheduleOverlap = New(30001, "PRICE_SCHEDULE_OVERLAP", "price schedule overlap") // 定时改价的生效时间段与已有的重叠
+	ErrPriceScheduleHandled = New(30002, "PRICE_SCHEDULE_HANDLED", "price schedule handled") // 定时改价已被其他实例处理
+
+	// 400xx 直播间商品
+	ErrGoodsAlreadyBound = New(40001, "GOODS_ALREADY_BOUND", "goods already bound to room") // 商品已绑定到直播间
+	ErrRoomGoodsNotFound = New(40002, "ROOM_GOODS_NOT_FOUND", "room goods not found")       // 直播间没有绑定该商品
+	ErrRoomGoodsMismatch = New(40003, "ROOM_GOODS_MISMATCH", "room goods mismatch")         // 排序的商品与直播间绑定的商品不一致
 )
+
+// VersionConflictError 乐观锁版本冲突，携带数据库中商品的当前版本号
+type VersionConflictError struct {
+	Current uint16 // 数据库中的当前版本号
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%v: current version %d", ErrVersionConflict, e.Current)
+}
+
+// Unwrap 使 errors.Is(err, ErrVersionConflict) 成立
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// StatusTransitionError 商品状态不允许从 From 变更为 To
+type StatusTransitionError struct {
+	From int8 // 商品当前状态
+	To   int8 // 目标状态
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("%v: from %d to %d", ErrStatusTransition, e.From, e.To)
+}
+
+// Unwrap 使 errors.Is(err, ErrStatusTransition) 成立
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrStatusTransition
+}
+
+// FieldError 请求中某个字段不合法，Err 为 ErrInvalidField 或 ErrInvalidUpdateMask
+type FieldError struct {
+	Err         *Error
+	Field       string // 字段名，例如 Price
+	Description string // 不合法的原因
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Description)
+}
+
+// Unwrap 使 errors.Is(err, e.Err) 成立
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidField 字段值不合法
+func InvalidField(field, description string) error {
+	return &FieldError{Err: ErrInvalidField, Field: field, Description: description}
+}
+
+// InvalidUpdateMask 字段掩码不合法
+func InvalidUpdateMask(description string) error {
+	return &FieldError{Err: ErrInvalidUpdateMask, Field: "UpdateMask", Description: description}
+}
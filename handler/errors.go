@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"goods_srv/errno"
+	"goods_srv/logger"
+	"goods_srv/proto"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// 业务错误到 gRPC 状态的转换
+// 每个 errno 错误对应一个 gRPC 状态码和返回给客户端的错误信息，
+// 并在状态详情中附带 google.rpc.ErrorInfo（业务错误码和错误原因），字段校验失败时附带 google.rpc.BadRequest
+
+// errorDomain ErrorInfo 中的错误域
+const errorDomain = "goods_srv"
+
+// errorMapping 业务错误对应的 gRPC 状态码和错误信息
+type errorMapping struct {
+	err     *errno.Error
+	code    codes.Code
+	message string
+}
+
+// errorMappings 所有业务错误的映射，未列出的错误返回 Internal
+var errorMappings = []errorMapping{
+	// 存储和缓存：数据库或 Redis 暂时不可用，客户端可以重试
+	{errno.ErrQueryFailed, codes.Unavailable, "服务暂时不可用，请稍后重试"},
+	{errno.ErrUpdateFailed, codes.Internal, "内部错误"},
+	{errno.ErrCreateFailed, codes.Internal, "内部错误"},
+	{errno.ErrDeleteFailed, codes.Internal, "内部错误"},
+	{errno.ErrCacheDeleteFailed, codes.Internal, "内部错误"},
+	{errno.ErrGetLockFailed, codes.Unavailable, "商品正在加载，请稍后重试"},
+
+	// 商品
+	{errno.ErrGoodsDetailNull, codes.NotFound, "商品不存在"},
+	{errno.ErrGoodsDetailNotFound, codes.NotFound, "商品不存在"},
+	{errno.ErrGoodsNotExist, codes.NotFound, "商品不存在"},
+	{errno.ErrGoodsAlreadyExist, codes.AlreadyExists, "商品已存在"},
+	{errno.ErrVersionConflict, codes.Aborted, "商品已被修改，请重新读取后重试"},
+	{errno.ErrStatusTransition, codes.FailedPrecondition, "商品当前状态不允许变更为目标状态"},
+	{errno.ErrInvalidUpdateMask, codes.InvalidArgument, "请求参数有误"},
+	{errno.ErrInvalidField, codes.InvalidArgument, "请求参数有误"},
+
+	// 定时改价
+	{errno.ErrPriceScheduleOverlap, codes.FailedPrecondition, "与已有的定时改价时间重叠"},
+	{errno.ErrPriceScheduleHandled, codes.Aborted, "定时改价已被处理"},
+
+	// 直播间商品
+	{errno.ErrGoodsAlreadyBound, codes.AlreadyExists, "商品已绑定到直播间"},
+	{errno.ErrRoomGoodsNotFound, codes.NotFound, "直播间没有绑定该商品"},
+	{errno.ErrRoomGoodsMismatch, codes.InvalidArgument, "排序的商品与直播间绑定的商品不一致"},
+}
+
+// toStatus 将业务错误转换为 gRPC 状态错误
+// 已经是 gRPC 状态的错误（例如流发送失败）原样返回，上下文取消或超时返回对应的状态
+func toStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var e *errno.Error
+	if !errors.As(err, &e) {
+		logger.FromContext(ctx).Error("Unknown error", zap.Error(err))
+		return status.Error(codes.Internal, "内部错误")
+	}
+	mapping, ok := findErrorMapping(e)
+	if !ok {
+		logger.FromContext(ctx).Error("Unmapped error", zap.Error(err))
+		mapping = errorMapping{err: e, code: codes.Internal, message: "内部错误"}
+	}
+	if mapping.code == codes.Internal || mapping.code == codes.Unavailable {
+		logger.FromContext(ctx).Error("Request failed", zap.Error(err))
+	}
+
+	st := status.New(mapping.code, mapping.message)
+	if withDetails, detailErr := st.WithDetails(errorDetails(err, e)...); detailErr == nil {
+		st = withDetails
+	} else {
+		logger.FromContext(ctx).Error("Failed to attach error details", zap.Error(detailErr))
+	}
+	return st.Err()
+}
+
+// findErrorMapping 查找业务错误对应的映射
+func findErrorMapping(e *errno.Error) (errorMapping, bool) {
+	for _, mapping := range errorMappings {
+		if mapping.err == e {
+			return mapping, true
+		}
+	}
+	return errorMapping{}, false
+}
+
+// errorDetails 生成状态详情：ErrorInfo 携带业务错误码、错误原因和错误的附加信息，字段错误附带 BadRequest
+func errorDetails(err error, e *errno.Error) []protoadapt.MessageV1 {
+	info := &errdetails.ErrorInfo{
+		Reason: e.Reason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"code": strconv.Itoa(e.Code),
+		},
+	}
+	details := []protoadapt.MessageV1{info}
+
+	var conflict *errno.VersionConflictError
+	if errors.As(err, &conflict) {
+		info.Metadata["current_version"] = strconv.Itoa(int(conflict.Current))
+	}
+	var transition *errno.StatusTransitionError
+	if errors.As(err, &transition) {
+		info.Metadata["from"] = proto.GoodsStatus(transition.From).String()
+		info.Metadata["to"] = proto.GoodsStatus(transition.To).String()
+	}
+	var field *errno.FieldError
+	if errors.As(err, &field) {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       field.Field,
+				Description: field.Description,
+			}},
+		})
+	}
+	return details
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"goods_srv/errno"
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusDetails 取出状态详情中的 ErrorInfo 和 BadRequest
+func statusDetails(t *testing.T, err error) (*errdetails.ErrorInfo, *errdetails.BadRequest) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("not a status error: %v", err)
+	}
+	var (
+		info       *errdetails.ErrorInfo
+		badRequest *errdetails.BadRequest
+	)
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	return info, badRequest
+}
+
+// exportedErrnoVars 解析 errno 包的源码，返回导出的 Err* 变量名
+func exportedErrnoVars(t *testing.T) []string {
+	t.Helper()
+	pkgs, err := parser.ParseDir(token.NewFileSet(), "../errno", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						if strings.HasPrefix(name.Name, "Err") {
+							names = append(names, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err  *errno.Error
+		code codes.Code
+	}{
+		{errno.ErrQueryFailed, codes.Unavailable},
+		{errno.ErrUpdateFailed, codes.Internal},
+		{errno.ErrCreateFailed, codes.Internal},
+		{errno.ErrDeleteFailed, codes.Internal},
+		{errno.ErrCacheDeleteFailed, codes.Internal},
+		{errno.ErrGetLockFailed, codes.Unavailable},
+		{errno.ErrGoodsDetailNull, codes.NotFound},
+		{errno.ErrGoodsDetailNotFound, codes.NotFound},
+		{errno.ErrGoodsNotExist, codes.NotFound},
+		{errno.ErrGoodsAlreadyExist, codes.AlreadyExists},
+		{errno.ErrVersionConflict, codes.Aborted},
+		{errno.ErrStatusTransition, codes.FailedPrecondition},
+		{errno.ErrInvalidUpdateMask, codes.InvalidArgument},
+		{errno.ErrInvalidField, codes.InvalidArgument},
+		{errno.ErrPriceScheduleOverlap, codes.FailedPrecondition},
+		{errno.ErrPriceScheduleHandled, codes.Aborted},
+		{errno.ErrGoodsAlreadyBound, codes.AlreadyExists},
+		{errno.ErrRoomGoodsNotFound, codes.NotFound},
+		{errno.ErrRoomGoodsMismatch, codes.InvalidArgument},
+	}
+
+	// errno 中导出的每个 Err* 都需要在测试中列出，映射表中的每个错误都需要被测试
+	exported := exportedErrnoVars(t)
+	seen := make(map[*errno.Error]bool, len(tests))
+	for _, tt := range tests {
+		if seen[tt.err] {
+			t.Errorf("errno %s is listed twice", tt.err.Reason)
+		}
+		seen[tt.err] = true
+	}
+	if len(seen) != len(exported) {
+		t.Errorf("%d errno values tested, errno exports %d: %v", len(seen), len(exported), exported)
+	}
+	for _, mapping := range errorMappings {
+		found := false
+		for _, tt := range tests {
+			found = found || tt.err == mapping.err
+		}
+		if !found {
+			t.Errorf("errno %s is mapped but not tested", mapping.err.Reason)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Reason, func(t *testing.T) {
+			// 业务代码返回的错误通常被包装过
+			err := toStatus(context.Background(), fmt.Errorf("wrapped: %w", tt.err))
+			if got := status.Code(err); got != tt.code {
+				t.Errorf("code = %s, want %s", got, tt.code)
+			}
+			info, _ := statusDetails(t, err)
+			if info == nil {
+				t.Fatal("missing ErrorInfo")
+			}
+			if info.Reason != tt.err.Reason {
+				t.Errorf("reason = %s, want %s", info.Reason, tt.err.Reason)
+			}
+			if info.Domain != errorDomain {
+				t.Errorf("domain = %s, want %s", info.Domain, errorDomain)
+			}
+			if got, want := info.Metadata["code"], strconv.Itoa(tt.err.Code); got != want {
+				t.Errorf("metadata code = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestToStatusFieldError(t *testing.T) {
+	tests := []struct {
+		err    error
+		reason string
+		field  string
+	}{
+		{errno.InvalidField("Price", "price must be positive"), errno.ErrInvalidField.Reason, "Price"},
+		{errno.InvalidUpdateMask("empty update mask"), errno.ErrInvalidUpdateMask.Reason, "UpdateMask"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			err := toStatus(context.Background(), tt.err)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Errorf("code = %s, want %s", got, codes.InvalidArgument)
+			}
+			info, badRequest := statusDetails(t, err)
+			if info == nil || info.Reason != tt.reason {
+				t.Errorf("ErrorInfo = %v, want reason %s", info, tt.reason)
+			}
+			if badRequest == nil || len(badRequest.FieldViolations) != 1 {
+				t.Fatalf("BadRequest = %v, want one field violation", badRequest)
+			}
+			violation := badRequest.FieldViolations[0]
+			var fieldErr *errno.FieldError
+			errors.As(tt.err, &fieldErr)
+			if violation.Field != tt.field || violation.Description != fieldErr.Description {
+				t.Errorf("field violation = %v, want field %s description %s", violation, tt.field, fieldErr.Description)
+			}
+		})
+	}
+}
+
+func TestToStatusVersionConflict(t *testing.T) {
+	err := toStatus(context.Background(), &errno.VersionConflictError{Current: 40000})
+	if got := status.Code(err); got != codes.Aborted {
+		t.Errorf("code = %s, want %s", got, codes.Aborted)
+	}
+	info, _ := statusDetails(t, err)
+	if info == nil || info.Reason != errno.ErrVersionConflict.Reason {
+		t.Fatalf("ErrorInfo = %v, want reason %s", info, errno.ErrVersionConflict.Reason)
+	}
+	if got := info.Metadata["current_version"]; got != "40000" {
+		t.Errorf("current_version = %s, want 40000", got)
+	}
+}
+
+func TestToStatusPassThrough(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"status", status.Error(codes.Unavailable, "stream closed"), codes.Unavailable},
+		{"canceled", fmt.Errorf("query: %w", context.Canceled), codes.Canceled},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"unknown", errors.New("boom"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(toStatus(context.Background(), tt.err)); got != tt.code {
+				t.Errorf("code = %s, want %s", got, tt.code)
+			}
+		})
+	}
+	if err := toStatus(context.Background(), nil); err != nil {
+		t.Errorf("toStatus(context.Background(), nil) = %v, want nil", err)
+	}
+}
@@ -2,12 +2,16 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"goods_srv/biz/goods"
+	"goods_srv/errno"
+	"goods_srv/logger"
 	"goods_srv/proto"
-	"log"
+	"math"
+	"time"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 // RPC的入口
@@ -22,58 +26,387 @@ func (s *GoodsSrv) GetGoodsByRoom(ctx context.Context, req *proto.GetGoodsByRoom
 	//参数处理
 	if req.GetRoomId() <= 0 {
 		//无效的请求
-		return nil, status.Error(codes.InvalidArgument, "请求参数有误")
+		return nil, invalidArgument(ctx, req, "RoomId", "RoomId must be positive")
 	}
 	// 去查询数据并封装返回的响应数据 --> 业务逻辑
-	data, err := goods.GetGoodsByRoom(ctx, req.GetRoomId())
+	data, err := goods.GetGoodsByRoom(ctx, req.GetRoomId(), req.GetIncludeUnsellable())
 	if err != nil {
-		return nil, status.Error(codes.Internal, "内部错误")
+		return nil, toStatus(ctx, err)
 	}
 	return data, nil
 }
+
 // GetGoodsDetail 根据goods_id获取商品详情
 func (s *GoodsSrv) GetGoodsDetail(ctx context.Context, req *proto.GetGoodsDetailReq) (*proto.GoodsDetail, error) {
-    log.Printf("Received GetGoodsDetail request: %+v", req)
+	logger.FromContext(ctx).Debug("Received GetGoodsDetail request", zap.Any("req", req))
 
-    if req.GetUserId() <= 0 || req.GetGoodsId() <= 0 {
-        log.Printf("Invalid request parameters: %+v", req)
-        return nil, status.Error(codes.InvalidArgument, "请求参数有误")
-    }
+	if req.GetUserId() <= 0 {
+		return nil, invalidArgument(ctx, req, "UserId", "UserId must be positive")
+	}
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
 
-    data, err := goods.GetGoodsDetailById(ctx, req.GetGoodsId())
-    if err != nil {
-        log.Printf("Failed to get goods detail: %v", err)
-        return nil, status.Error(codes.Internal, "内部错误")
-    }
+	data, err := goods.GetGoodsDetailById(ctx, req.GetGoodsId())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to get goods detail", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
 
-    log.Printf("Returning response: %+v", data)
-    return data, nil
+	logger.FromContext(ctx).Debug("Returning response", zap.Any("resp", data))
+	return data, nil
 }
 
 func (s *GoodsSrv) UpdateGoodsDetail(ctx context.Context, req *proto.UpdateGoodsDetailReq) (*proto.Response, error) {
-    log.Printf("Received UpdateGoodsDetail request: %+v", req)
-
-    if req.GetGoodsId() <= 0 {
-        log.Printf("Invalid request parameters: %+v", req)
-        return nil, status.Error(codes.InvalidArgument, "请求参数有误")
-    }
-    if req.GetPrice() <= 0 {
-        log.Printf("Invalid request parameters: %+v", req)
-        return nil, status.Error(codes.InvalidArgument, "请求参数有误")
-    }
-
-    // 更新数据库中的商品信息
-    _,err := goods.UpdateGoodsDetail(ctx, req.GetGoodsId(),req.GetPrice())
-    if err != nil {
-        log.Printf("Failed to update goods detail: %v", err)
-        return nil, status.Error(codes.Internal, "内部错误")
-    }
-
-
-    // 3. 返回成功响应
-    log.Printf("Goods detail updated successfully for GoodsId: %d", req.GetGoodsId())
-    return &proto.Response{
-        Success: true,
-        Message: "商品信息更新成功",
-    }, nil
+	logger.FromContext(ctx).Debug("Received UpdateGoodsDetail request", zap.Any("req", req))
+
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
+	if req.GetPrice() <= 0 {
+		return nil, invalidArgument(ctx, req, "Price", "Price must be a positive amount")
+	}
+
+	expectedVersion, ok := toExpectedVersion(req.ExpectedVersion)
+	if !ok {
+		return nil, invalidArgument(ctx, req, "ExpectedVersion", "ExpectedVersion must be between 0 and 65535")
+	}
+
+	// 更新数据库中的商品信息
+	_, err := goods.UpdateGoodsDetail(ctx, req.GetGoodsId(), req.GetPrice(), expectedVersion, req.GetReason())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to update goods detail", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+
+	// 3. 返回成功响应
+	logger.FromContext(ctx).Info("Goods detail updated successfully", zap.Int64("goods_id", req.GetGoodsId()))
+	return &proto.Response{
+		Success: true,
+		Message: "商品信息更新成功",
+	}, nil
+}
+
+// UpdateGoods 按字段掩码部分更新商品信息
+func (s *GoodsSrv) UpdateGoods(ctx context.Context, req *proto.UpdateGoodsReq) (*proto.GoodsDetail, error) {
+	if req.GetGoods().GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "Goods.GoodsId", "GoodsId must be positive")
+	}
+	expectedVersion, ok := toExpectedVersion(req.ExpectedVersion)
+	if !ok {
+		return nil, invalidArgument(ctx, req, "ExpectedVersion", "ExpectedVersion must be between 0 and 65535")
+	}
+
+	data, err := goods.UpdateGoods(ctx, req.GetGoods(), req.GetUpdateMask().GetPaths(), expectedVersion, req.GetReason())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to update goods", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return data, nil
+}
+
+// GetGoodsHistory 分页查询商品的修改日志
+func (s *GoodsSrv) GetGoodsHistory(ctx context.Context, req *proto.GetGoodsHistoryReq) (*proto.GoodsHistoryResp, error) {
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
+	if err := validatePage(req.GetPage(), req.GetPageSize()); err != nil {
+		return nil, invalidRequest(ctx, req, err)
+	}
+
+	data, err := goods.GetGoodsHistory(ctx, req.GetGoodsId(), int(req.GetPage()), int(req.GetPageSize()))
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to get goods history", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return data, nil
+}
+
+// SchedulePriceChange 创建定时改价
+func (s *GoodsSrv) SchedulePriceChange(ctx context.Context, req *proto.SchedulePriceChangeReq) (*proto.PriceChange, error) {
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
+	if req.GetPrice() <= 0 {
+		return nil, invalidArgument(ctx, req, "Price", "Price must be a positive amount")
+	}
+	if req.GetEffectiveFrom() <= time.Now().Unix() {
+		return nil, invalidArgument(ctx, req, "EffectiveFrom", "EffectiveFrom must be in the future")
+	}
+	if req.GetEffectiveTo() != 0 && req.GetEffectiveTo() <= req.GetEffectiveFrom() {
+		return nil, invalidArgument(ctx, req, "EffectiveTo", "EffectiveTo must be 0 or after EffectiveFrom")
+	}
+
+	var effectiveTo time.Time
+	if req.GetEffectiveTo() != 0 {
+		effectiveTo = time.Unix(req.GetEffectiveTo(), 0)
+	}
+	data, err := goods.SchedulePriceChange(ctx, req.GetGoodsId(), req.GetPrice(),
+		time.Unix(req.GetEffectiveFrom(), 0), effectiveTo, req.GetReason())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to schedule price change", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return data, nil
+}
+
+// GetPriceHistory 查询商品的价格时间线
+func (s *GoodsSrv) GetPriceHistory(ctx context.Context, req *proto.GetPriceHistoryReq) (*proto.PriceHistoryResp, error) {
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
+
+	data, err := goods.GetPriceHistory(ctx, req.GetGoodsId())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to get price history", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return data, nil
+}
+
+// BindGoodsToRoom 将商品绑定到直播间
+func (s *GoodsSrv) BindGoodsToRoom(ctx context.Context, req *proto.BindGoodsToRoomReq) (*proto.Response, error) {
+	if req.GetRoomId() <= 0 {
+		return nil, invalidArgument(ctx, req, "RoomId", "RoomId must be positive")
+	}
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
+	if req.GetWeight() < 0 {
+		return nil, invalidArgument(ctx, req, "Weight", "Weight must be non-negative")
+	}
+	if err := goods.BindGoodsToRoom(ctx, req.GetRoomId(), req.GetGoodsId(), req.GetWeight()); err != nil {
+		logger.FromContext(ctx).Warn("Failed to update room goods", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.Response{Success: true, Message: "商品绑定成功"}, nil
+}
+
+// UnbindGoodsFromRoom 解绑直播间的商品
+func (s *GoodsSrv) UnbindGoodsFromRoom(ctx context.Context, req *proto.UnbindGoodsFromRoomReq) (*proto.Response, error) {
+	if req.GetRoomId() <= 0 {
+		return nil, invalidArgument(ctx, req, "RoomId", "RoomId must be positive")
+	}
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
+	if err := goods.UnbindGoodsFromRoom(ctx, req.GetRoomId(), req.GetGoodsId()); err != nil {
+		logger.FromContext(ctx).Warn("Failed to update room goods", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.Response{Success: true, Message: "商品解绑成功"}, nil
+}
+
+// ReorderRoomGoods 重新排列直播间的商品
+func (s *GoodsSrv) ReorderRoomGoods(ctx context.Context, req *proto.ReorderRoomGoodsReq) (*proto.Response, error) {
+	if req.GetRoomId() <= 0 {
+		return nil, invalidArgument(ctx, req, "RoomId", "RoomId must be positive")
+	}
+	if err := goods.ReorderRoomGoods(ctx, req.GetRoomId(), req.GetGoodsIds()); err != nil {
+		logger.FromContext(ctx).Warn("Failed to update room goods", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.Response{Success: true, Message: "商品排序成功"}, nil
+}
+
+// SetCurrentGoods 切换直播间当前讲解的商品
+func (s *GoodsSrv) SetCurrentGoods(ctx context.Context, req *proto.SetCurrentGoodsReq) (*proto.Response, error) {
+	if req.GetRoomId() <= 0 {
+		return nil, invalidArgument(ctx, req, "RoomId", "RoomId must be positive")
+	}
+	if req.GetGoodsId() < 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be 0 or positive")
+	}
+	if err := goods.SetCurrentGoods(ctx, req.GetRoomId(), req.GetGoodsId()); err != nil {
+		logger.FromContext(ctx).Warn("Failed to update room goods", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.Response{Success: true, Message: "当前讲解商品切换成功"}, nil
+}
+
+// toExpectedVersion 将请求中的期望版本号转换为数据库中的版本号类型，未传时返回 nil
+func toExpectedVersion(v *int32) (*uint16, bool) {
+	if v == nil {
+		return nil, true
+	}
+	if *v < 0 || *v > math.MaxUint16 {
+		return nil, false
+	}
+	version := uint16(*v)
+	return &version, true
+}
+
+// maxBatchGoodsIds 批量获取商品详情时单次请求最多的商品数
+const maxBatchGoodsIds = 100
+
+// BatchGetGoodsDetail 批量获取商品详情，结果按请求中的商品顺序返回
+func (s *GoodsSrv) BatchGetGoodsDetail(ctx context.Context, req *proto.BatchGetGoodsDetailReq) (*proto.BatchGoodsDetailResp, error) {
+	goodsIds := req.GetGoodsIds()
+	if req.GetUserId() <= 0 {
+		return nil, invalidArgument(ctx, req, "UserId", "UserId must be positive")
+	}
+	if len(goodsIds) == 0 || len(goodsIds) > maxBatchGoodsIds {
+		return nil, invalidArgument(ctx, req, "GoodsIds", fmt.Sprintf("GoodsIds must contain 1-%d ids", maxBatchGoodsIds))
+	}
+	for i, goodsId := range goodsIds {
+		if goodsId <= 0 {
+			return nil, invalidArgument(ctx, req, fmt.Sprintf("GoodsIds[%d]", i), "GoodsId must be positive")
+		}
+	}
+
+	data, err := goods.BatchGetGoodsDetail(ctx, goodsIds)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to batch get goods detail", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return data, nil
+}
+
+// maxPageSize 商品列表每页最多的条数
+const maxPageSize = 100
+
+// validatePage 校验分页参数，0 表示使用默认值
+func validatePage(page, pageSize int32) error {
+	if page < 0 {
+		return errno.InvalidField("Page", "Page must be non-negative")
+	}
+	if pageSize < 0 || pageSize > maxPageSize {
+		return errno.InvalidField("PageSize", fmt.Sprintf("PageSize must be 0-%d", maxPageSize))
+	}
+	return nil
+}
+
+// CreateGoods 新增商品
+func (s *GoodsSrv) CreateGoods(ctx context.Context, req *proto.CreateGoodsReq) (*proto.GoodsDetail, error) {
+	if err := validateCreateGoods(req); err != nil {
+		return nil, invalidRequest(ctx, req, err)
+	}
+
+	data, err := goods.CreateGoods(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to create goods", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return data, nil
+}
+
+// DeleteGoods 删除商品（软删除）
+func (s *GoodsSrv) DeleteGoods(ctx context.Context, req *proto.DeleteGoodsReq) (*proto.Response, error) {
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
+
+	err := goods.DeleteGoods(ctx, req.GetGoodsId(), req.GetReason())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to delete goods", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return &proto.Response{
+		Success: true,
+		Message: "商品删除成功",
+	}, nil
+}
+
+// ChangeGoodsStatus 修改商品状态，当前状态不允许变更为目标状态时返回 FailedPrecondition
+func (s *GoodsSrv) ChangeGoodsStatus(ctx context.Context, req *proto.ChangeGoodsStatusReq) (*proto.GoodsDetail, error) {
+	if req.GetGoodsId() <= 0 {
+		return nil, invalidArgument(ctx, req, "GoodsId", "GoodsId must be positive")
+	}
+	if !validGoodsStatus(req.GetStatus()) {
+		return nil, invalidArgument(ctx, req, "Status", "Status is not a defined goods status")
+	}
+
+	data, err := goods.ChangeGoodsStatus(ctx, req.GetGoodsId(), req.GetStatus(), req.GetReason())
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to change goods status", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return data, nil
+}
+
+// validateCreateGoods 校验新增商品的请求参数
+func validateCreateGoods(req *proto.CreateGoodsReq) error {
+	switch {
+	case req.GetGoodsId() <= 0:
+		return errno.InvalidField("GoodsId", "GoodsId must be positive")
+	case req.GetCategoryId() <= 0:
+		return errno.InvalidField("CategoryId", "CategoryId must be positive")
+	case req.GetCode() == "" || len(req.GetCode()) > 64:
+		return errno.InvalidField("Code", "Code must be 1-64 bytes")
+	case req.GetTitle() == "" || len(req.GetTitle()) > 255:
+		return errno.InvalidField("Title", "Title must be 1-255 bytes")
+	case len(req.GetBrandName()) > 255:
+		return errno.InvalidField("BrandName", "BrandName must be at most 255 bytes")
+	case len(req.GetBrief()) > 255:
+		return errno.InvalidField("Brief", "Brief must be at most 255 bytes")
+	case req.GetPrice() <= 0:
+		return errno.InvalidField("Price", "Price must be a positive amount")
+	case req.GetMarketPrice() < 0:
+		return errno.InvalidField("MarketPrice", "MarketPrice must be a non-negative amount")
+	case !validGoodsStatus(req.GetStatus()) || req.GetStatus() == proto.GoodsStatus_GOODS_STATUS_DELETED:
+		return errno.InvalidField("Status", "Status must be a defined goods status other than deleted")
+	}
+	return nil
+}
+
+// validGoodsStatus 判断商品状态是否为已定义的取值
+func validGoodsStatus(s proto.GoodsStatus) bool {
+	_, ok := proto.GoodsStatus_name[int32(s)]
+	return ok
+}
+
+// ListGoods 按条件分页查询商品列表
+func (s *GoodsSrv) ListGoods(ctx context.Context, req *proto.ListGoodsReq) (*proto.ListGoodsResp, error) {
+	if err := validatePage(req.GetPage(), req.GetPageSize()); err != nil {
+		return nil, invalidRequest(ctx, req, err)
+	}
+
+	data, err := goods.ListGoods(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to list goods", zap.Error(err))
+		return nil, toStatus(ctx, err)
+	}
+	return data, nil
+}
+
+// GetHotKeys 管理接口，查询当前实例探测到的热点商品
+func (s *GoodsSrv) GetHotKeys(ctx context.Context, req *proto.GetHotKeysReq) (*proto.HotKeysResp, error) {
+	return goods.GetHotKeys(ctx)
+}
+
+// WatchRoomGoods 订阅直播间商品变更，先推送商品列表快照，再推送增量事件
+func (s *GoodsSrv) WatchRoomGoods(req *proto.WatchRoomGoodsReq, stream grpc.ServerStreamingServer[proto.RoomGoodsEvent]) error {
+	if req.GetRoomId() <= 0 {
+		return invalidArgument(stream.Context(), req, "RoomId", "RoomId must be positive")
+	}
+	err := goods.WatchRoomGoods(stream.Context(), req.GetRoomId(), req.GetResumeToken(), stream.Send)
+	if err != nil {
+		logger.FromContext(stream.Context()).Warn("Failed to watch room goods", zap.Int64("room_id", req.GetRoomId()), zap.Error(err))
+		return toStatus(stream.Context(), err)
+	}
+	return nil
+}
+
+// WarmUpRoom 管理接口，预热直播间商品缓存并流式返回预热进度
+func (s *GoodsSrv) WarmUpRoom(req *proto.WarmUpRoomReq, stream grpc.ServerStreamingServer[proto.WarmUpProgress]) error {
+	if req.GetRoomId() <= 0 {
+		return invalidArgument(stream.Context(), req, "RoomId", "RoomId must be positive")
+	}
+	err := goods.WarmUpRoom(stream.Context(), req.GetRoomId(), stream.Send)
+	if err != nil {
+		logger.FromContext(stream.Context()).Warn("Failed to warm up room", zap.Int64("room_id", req.GetRoomId()), zap.Error(err))
+		return toStatus(stream.Context(), err)
+	}
+	return nil
+}
+
+// invalidArgument 记录请求参数错误，返回带字段错误详情的 InvalidArgument 状态
+func invalidArgument(ctx context.Context, req any, field, description string) error {
+	return invalidRequest(ctx, req, errno.InvalidField(field, description))
+}
+
+// invalidRequest 记录请求参数错误并转换为 gRPC 状态，err 为 errno.FieldError
+func invalidRequest(ctx context.Context, req any, err error) error {
+	logger.FromContext(ctx).Warn("Invalid request parameters", zap.Any("req", req), zap.Error(err))
+	return toStatus(ctx, err)
 }
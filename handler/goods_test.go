@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"goods_srv/errno"
+	"goods_srv/proto"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchStream 只实现 Context 的直播间商品事件流
+type watchStream struct {
+	grpc.ServerStreamingServer[proto.RoomGoodsEvent]
+	ctx context.Context
+}
+
+func (s watchStream) Context() context.Context {
+	return s.ctx
+}
+
+// TestInvalidArgumentFieldViolations 参数校验失败时返回 InvalidArgument，并在 BadRequest 中指出不合法的字段
+func TestInvalidArgumentFieldViolations(t *testing.T) {
+	s := &GoodsSrv{}
+	ctx := context.Background()
+	version := func(v int32) *int32 { return &v }
+	future := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{"GetGoodsByRoom room_id", func() error {
+			_, err := s.GetGoodsByRoom(ctx, &proto.GetGoodsByRoomReq{UserId: 1})
+			return err
+		}, "RoomId"},
+		{"GetGoodsDetail user_id", func() error {
+			_, err := s.GetGoodsDetail(ctx, &proto.GetGoodsDetailReq{GoodsId: 1})
+			return err
+		}, "UserId"},
+		{"GetGoodsDetail goods_id", func() error {
+			_, err := s.GetGoodsDetail(ctx, &proto.GetGoodsDetailReq{UserId: 1})
+			return err
+		}, "GoodsId"},
+		{"UpdateGoodsDetail price", func() error {
+			_, err := s.UpdateGoodsDetail(ctx, &proto.UpdateGoodsDetailReq{GoodsId: 1})
+			return err
+		}, "Price"},
+		{"UpdateGoodsDetail expected_version", func() error {
+			_, err := s.UpdateGoodsDetail(ctx, &proto.UpdateGoodsDetailReq{GoodsId: 1, Price: 100, ExpectedVersion: version(70000)})
+			return err
+		}, "ExpectedVersion"},
+		{"UpdateGoods goods_id", func() error {
+			_, err := s.UpdateGoods(ctx, &proto.UpdateGoodsReq{})
+			return err
+		}, "Goods.GoodsId"},
+		{"UpdateGoods expected_version", func() error {
+			_, err := s.UpdateGoods(ctx, &proto.UpdateGoodsReq{Goods: &proto.GoodsDetail{GoodsId: 1}, ExpectedVersion: version(-1)})
+			return err
+		}, "ExpectedVersion"},
+		{"GetGoodsHistory page_size", func() error {
+			_, err := s.GetGoodsHistory(ctx, &proto.GetGoodsHistoryReq{GoodsId: 1, PageSize: maxPageSize + 1})
+			return err
+		}, "PageSize"},
+		{"SchedulePriceChange effective_from", func() error {
+			_, err := s.SchedulePriceChange(ctx, &proto.SchedulePriceChangeReq{GoodsId: 1, Price: 100, EffectiveFrom: 1})
+			return err
+		}, "EffectiveFrom"},
+		{"SchedulePriceChange effective_to", func() error {
+			_, err := s.SchedulePriceChange(ctx, &proto.SchedulePriceChangeReq{GoodsId: 1, Price: 100, EffectiveFrom: future, EffectiveTo: future})
+			return err
+		}, "EffectiveTo"},
+		{"BindGoodsToRoom weight", func() error {
+			_, err := s.BindGoodsToRoom(ctx, &proto.BindGoodsToRoomReq{RoomId: 1, GoodsId: 1, Weight: -1})
+			return err
+		}, "Weight"},
+		{"SetCurrentGoods goods_id", func() error {
+			_, err := s.SetCurrentGoods(ctx, &proto.SetCurrentGoodsReq{RoomId: 1, GoodsId: -1})
+			return err
+		}, "GoodsId"},
+		{"BatchGetGoodsDetail ids length", func() error {
+			_, err := s.BatchGetGoodsDetail(ctx, &proto.BatchGetGoodsDetailReq{UserId: 1, GoodsIds: make([]int64, maxBatchGoodsIds+1)})
+			return err
+		}, "GoodsIds"},
+		{"BatchGetGoodsDetail goods_id", func() error {
+			_, err := s.BatchGetGoodsDetail(ctx, &proto.BatchGetGoodsDetailReq{UserId: 1, GoodsIds: []int64{1, 0}})
+			return err
+		}, "GoodsIds[1]"},
+		{"CreateGoods code", func() error {
+			_, err := s.CreateGoods(ctx, &proto.CreateGoodsReq{GoodsId: 1, CategoryId: 1, Code: strings.Repeat("c", 65), Title: "t", Price: 100})
+			return err
+		}, "Code"},
+		{"CreateGoods status", func() error {
+			_, err := s.CreateGoods(ctx, &proto.CreateGoodsReq{GoodsId: 1, CategoryId: 1, Code: "c", Title: "t", Price: 100,
+				Status: proto.GoodsStatus_GOODS_STATUS_DELETED})
+			return err
+		}, "Status"},
+		{"ChangeGoodsStatus status", func() error {
+			_, err := s.ChangeGoodsStatus(ctx, &proto.ChangeGoodsStatusReq{GoodsId: 1, Status: 100})
+			return err
+		}, "Status"},
+		{"ListGoods page", func() error {
+			_, err := s.ListGoods(ctx, &proto.ListGoodsReq{Page: -1})
+			return err
+		}, "Page"},
+		{"DeleteGoods goods_id", func() error {
+			_, err := s.DeleteGoods(ctx, &proto.DeleteGoodsReq{})
+			return err
+		}, "GoodsId"},
+		{"WatchRoomGoods room_id", func() error {
+			return s.WatchRoomGoods(&proto.WatchRoomGoodsReq{}, watchStream{ctx: ctx})
+		}, "RoomId"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Fatalf("code = %s, want %s: %v", got, codes.InvalidArgument, err)
+			}
+			info, badRequest := statusDetails(t, err)
+			if info == nil || info.Reason != errno.ErrInvalidField.Reason || info.Domain != errorDomain {
+				t.Errorf("ErrorInfo = %v, want reason %s", info, errno.ErrInvalidField.Reason)
+			}
+			if badRequest == nil || len(badRequest.FieldViolations) != 1 {
+				t.Fatalf("BadRequest = %v, want one field violation", badRequest)
+			}
+			if v := badRequest.FieldViolations[0]; v.Field != tt.field || v.Description == "" {
+				t.Errorf("field violation = %v, want field %s", v, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package hotkey
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// 热点 key 探测
+// 每个 key 使用滑动窗口计数，窗口内的请求数达到阈值即判定为热点
+
+// HotKey 热点 key 及其窗口内的请求数
+type HotKey struct {
+	Key   string
+	Count uint64
+}
+
+// Options 探测器配置
+type Options struct {
+	Window    time.Duration // 滑动窗口大小
+	Buckets   int           // 窗口划分的桶数，桶越多计数越平滑
+	Threshold uint64        // 窗口内请求数达到该值即为热点
+	MaxKeys   int           // 最多同时存在的热点 key 数，<=0 表示不限制
+}
+
+// counter 滑动窗口计数器，环形数组中每个桶记录一个时间片内的请求数
+type counter struct {
+	counts []uint64
+	starts []int64 // 每个桶对应时间片的起始时间（纳秒）
+}
+
+// Detector 热点 key 探测器
+type Detector struct {
+	opts       Options
+	bucketSize int64
+
+	mu       sync.Mutex
+	counters map[string]*counter
+	hot      map[string]uint64 // 当前的热点 key 及最近一次统计的请求数
+}
+
+// New 创建热点 key 探测器
+func New(opts Options) *Detector {
+	if opts.Buckets <= 0 {
+		opts.Buckets = 10
+	}
+	// 窗口未配置或小于桶数（纳秒）时每个桶至少 1 纳秒，避免 Record 中除零
+	bucketSize := max(int64(opts.Window)/int64(opts.Buckets), 1)
+	return &Detector{
+		opts:       opts,
+		bucketSize: bucketSize,
+		counters:   make(map[string]*counter),
+		hot:        make(map[string]uint64),
+	}
+}
+
+// Record 记录一次请求，返回该 key 当前是否为热点
+func (d *Detector) Record(key string) bool {
+	now := time.Now().UnixNano()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.counters[key]
+	if !ok {
+		c = &counter{counts: make([]uint64, d.opts.Buckets), starts: make([]int64, d.opts.Buckets)}
+		d.counters[key] = c
+	}
+	start := now - now%d.bucketSize
+	idx := int(start/d.bucketSize) % d.opts.Buckets
+	if c.starts[idx] != start {
+		// 桶已过期，重新计数
+		c.starts[idx], c.counts[idx] = start, 0
+	}
+	c.counts[idx]++
+
+	count := d.sum(c, now)
+	if _, isHot := d.hot[key]; isHot {
+		d.hot[key] = count
+		return true
+	}
+	if count < d.opts.Threshold {
+		return false
+	}
+	if d.opts.MaxKeys > 0 && len(d.hot) >= d.opts.MaxKeys {
+		return false
+	}
+	d.hot[key] = count
+	return true
+}
+
+// IsHot 判断 key 当前是否为热点
+func (d *Detector) IsHot(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.hot[key]
+	return ok
+}
+
+// HotKeys 返回当前的热点 key，按请求数从高到低排序
+func (d *Detector) HotKeys() []HotKey {
+	d.mu.Lock()
+	list := make([]HotKey, 0, len(d.hot))
+	for key, count := range d.hot {
+		list = append(list, HotKey{Key: key, Count: count})
+	}
+	d.mu.Unlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].Count > list[j].Count })
+	return list
+}
+
+// Sweep 重新统计所有 key，请求数低于阈值的热点 key 降级，窗口内没有请求的计数器被删除
+// 返回本次降级的 key，需要定期调用
+func (d *Detector) Sweep() []string {
+	now := time.Now().UnixNano()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var cooled []string
+	for key, c := range d.counters {
+		count := d.sum(c, now)
+		if count == 0 {
+			delete(d.counters, key)
+		}
+		if _, isHot := d.hot[key]; !isHot {
+			continue
+		}
+		if count < d.opts.Threshold {
+			delete(d.hot, key)
+			cooled = append(cooled, key)
+		} else {
+			d.hot[key] = count
+		}
+	}
+	return cooled
+}
+
+// sum 统计窗口内的请求数，调用方需持有锁
+func (d *Detector) sum(c *counter, now int64) uint64 {
+	var total uint64
+	windowStart := now - int64(d.opts.Window)
+	for i, start := range c.starts {
+		if start > windowStart {
+			total += c.counts[i]
+		}
+	}
+	return total
+}
@@ -0,0 +1,158 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"goods_srv/logger"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC 服务端拦截器
+// 按顺序注册：RequestID 为请求分配请求 ID 并创建请求级别的 logger，
+// AccessLog 记录访问日志，Recovery 放在最内层，将 handler 中的 panic 转换为 Internal 错误，避免进程退出。
+
+const (
+	// RequestIDMetadataKey 请求 metadata 中请求 ID 的 key，客户端未携带时由服务端生成，并通过响应 header 返回
+	RequestIDMetadataKey = "x-request-id"
+
+	// maxRequestIDLength 客户端携带的请求 ID 的最大长度，超过时重新生成
+	maxRequestIDLength = 64
+)
+
+type requestIDKey struct{}
+
+// RequestIDFromContext 返回当前请求的请求 ID
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// UnaryRequestID 一元调用的请求 ID 拦截器
+func UnaryRequestID(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withRequestID(ctx, info.FullMethod), req)
+}
+
+// StreamRequestID 流式调用的请求 ID 拦截器
+func StreamRequestID(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: withRequestID(ss.Context(), info.FullMethod)})
+}
+
+// UnaryAccessLog 一元调用的访问日志拦截器
+func UnaryAccessLog(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	accessLog(ctx, start, err)
+	return resp, err
+}
+
+// StreamAccessLog 流式调用的访问日志拦截器，在流结束时记录
+func StreamAccessLog(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	accessLog(ss.Context(), start, err)
+	return err
+}
+
+// UnaryRecovery 一元调用的 panic 恢复拦截器
+func UnaryRecovery(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ctx, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// StreamRecovery 流式调用的 panic 恢复拦截器
+func StreamRecovery(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// withRequestID 从 metadata 中取出请求 ID，没有时生成一个，并将请求 ID 和请求级别的 logger 放入 context
+func withRequestID(ctx context.Context, method string) context.Context {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDMetadataKey); len(values) > 0 && len(values[0]) <= maxRequestIDLength {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	// 通过响应 header 返回请求 ID，方便客户端反馈问题时定位日志
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID)); err != nil {
+		logger.FromContext(ctx).Warn("Failed to set request id header", zap.Error(err))
+	}
+
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	l := logger.FromContext(ctx).With(
+		zap.String("request_id", requestID),
+		zap.String("method", method),
+	)
+	return logger.WithContext(ctx, l)
+}
+
+// newRequestID 生成 16 字节的随机请求 ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// accessLog 记录请求的状态码、耗时和客户端地址，服务端错误使用 Error 级别
+func accessLog(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.String("code", code.String()),
+		zap.Duration("latency", time.Since(start)),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+	level := zapcore.InfoLevel
+	if err != nil {
+		level = zapcore.WarnLevel
+		switch code {
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = zapcore.ErrorLevel
+		}
+		fields = append(fields, zap.Error(err))
+	}
+	logger.FromContext(ctx).Log(level, "access", fields...)
+}
+
+// recovered 记录 panic 和调用栈，返回 Internal 错误
+func recovered(ctx context.Context, r any) error {
+	logger.FromContext(ctx).Error("Recovered from panic",
+		zap.Any("panic", r),
+		zap.ByteString("stack", debug.Stack()),
+	)
+	return status.Error(codes.Internal, "内部错误")
+}
+
+// serverStream 替换流的 context，使 handler 能取到请求 ID 和请求级别的 logger
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
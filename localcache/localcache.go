@@ -0,0 +1,265 @@
+package localcache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 进程内本地缓存
+// 1. 按条目数和字节数双重限制容量，超出时按 LRU 或 LFU 策略淘汰
+// 2. 每个条目单独设置过期时间，读取时惰性判断是否过期，不为每个条目启动 goroutine
+
+const (
+	PolicyLRU = "lru" // 淘汰最近最少使用的条目
+	PolicyLFU = "lfu" // 淘汰访问频率最低的条目
+
+	// 未配置时的默认容量
+	defaultMaxEntries = 100000
+)
+
+// Options 本地缓存配置
+type Options struct {
+	MaxEntries int    // 最大条目数，<=0 时使用默认值
+	MaxBytes   int64  // 最大字节数，<=0 表示不限制
+	Policy     string // 淘汰策略：lru / lfu，默认 lru
+}
+
+// Stats 本地缓存统计信息
+type Stats struct {
+	Hits        uint64 // 命中次数
+	Misses      uint64 // 未命中次数（包括已过期）
+	Evictions   uint64 // 因容量不足被淘汰的条目数
+	Expirations uint64 // 因过期被删除的条目数
+	Entries     int    // 当前条目数
+	Bytes       int64  // 当前占用字节数
+}
+
+// entry 缓存条目
+type entry struct {
+	key      string
+	value    interface{}
+	size     int64
+	expireAt time.Time // 零值表示永不过期
+	pinned   bool      // 固定的条目不参与容量淘汰，只会过期或被删除
+
+	// 以下字段由淘汰策略维护
+	freq       uint64    // 访问次数（LFU）
+	accessedAt time.Time // 最近访问时间（LFU 同频率时淘汰更久未访问的）
+	index      int       // 在淘汰策略内部结构中的位置
+	elem       interface{}
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && now.After(e.expireAt)
+}
+
+// evictor 淘汰策略
+type evictor interface {
+	add(e *entry)    // 新增条目
+	touch(e *entry)  // 条目被访问
+	remove(e *entry) // 删除条目
+	victim() *entry  // 返回下一个应被淘汰的条目
+}
+
+// Cache 并发安全的本地缓存
+type Cache struct {
+	mu         sync.Mutex
+	items      map[string]*entry
+	evictor    evictor
+	maxEntries int
+	maxBytes   int64
+	usedBytes  int64
+
+	hits, misses, evictions, expirations atomic.Uint64
+}
+
+// New 创建本地缓存
+func New(opts Options) *Cache {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = defaultMaxEntries
+	}
+	c := &Cache{
+		items:      make(map[string]*entry),
+		maxEntries: opts.MaxEntries,
+		maxBytes:   opts.MaxBytes,
+	}
+	switch opts.Policy {
+	case PolicyLFU:
+		c.evictor = newLFU()
+	default:
+		c.evictor = newLRU()
+	}
+	return c
+}
+
+// Get 获取缓存，条目不存在或已过期时返回 false
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	now := time.Now()
+	if e.expired(now) {
+		c.removeEntry(e)
+		c.expirations.Add(1)
+		c.misses.Add(1)
+		return nil, false
+	}
+	e.accessedAt = now
+	if !e.pinned {
+		c.evictor.touch(e)
+	}
+	c.hits.Add(1)
+	return e.value, true
+}
+
+// TTL 返回条目的剩余有效时间，条目不存在或已过期时返回 false
+// 永不过期的条目返回 0 和 true
+func (c *Cache) TTL(key string) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok || e.expired(time.Now()) {
+		return 0, false
+	}
+	if e.expireAt.IsZero() {
+		return 0, true
+	}
+	return time.Until(e.expireAt), true
+}
+
+// Set 写入缓存，size 为条目占用的字节数估算值，ttl<=0 表示永不过期
+// 覆盖固定的条目时保持固定状态，需要调用 Unpin 取消
+func (c *Cache) Set(key string, value interface{}, size int64, ttl time.Duration) {
+	c.set(key, value, size, ttl, false)
+}
+
+// SetPinned 写入固定的缓存条目，容量不足时不会被淘汰，用于热点数据
+func (c *Cache) SetPinned(key string, value interface{}, size int64, ttl time.Duration) {
+	c.set(key, value, size, ttl, true)
+}
+
+// Pin 固定已有的条目，条目不存在或已过期时返回 false
+func (c *Cache) Pin(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok || e.expired(time.Now()) {
+		return false
+	}
+	if !e.pinned {
+		c.evictor.remove(e)
+		e.pinned = true
+	}
+	return true
+}
+
+// Unpin 取消条目的固定状态，之后按淘汰策略正常淘汰
+func (c *Cache) Unpin(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok && e.pinned {
+		e.pinned = false
+		c.evictor.add(e)
+	}
+}
+
+// Pinned 判断条目是否处于固定状态
+func (c *Cache) Pinned(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	return ok && e.pinned
+}
+
+func (c *Cache) set(key string, value interface{}, size int64, ttl time.Duration, pinned bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = now.Add(ttl)
+	}
+
+	if e, ok := c.items[key]; ok {
+		c.usedBytes += size - e.size
+		e.value, e.size, e.expireAt, e.accessedAt = value, size, expireAt, now
+		switch {
+		case e.pinned:
+			// 已固定的条目保持固定
+		case pinned:
+			c.evictor.remove(e)
+			e.pinned = true
+		default:
+			c.evictor.touch(e)
+		}
+	} else {
+		e = &entry{key: key, value: value, size: size, expireAt: expireAt, accessedAt: now, pinned: pinned}
+		c.items[key] = e
+		c.usedBytes += size
+		if !pinned {
+			c.evictor.add(e)
+		}
+	}
+
+	// 超出容量时按淘汰策略淘汰条目，固定的条目不参与淘汰
+	for len(c.items) > c.maxEntries || (c.maxBytes > 0 && c.usedBytes > c.maxBytes) {
+		victim := c.evictor.victim()
+		if victim == nil {
+			break
+		}
+		c.removeEntry(victim)
+		if victim.expired(now) {
+			c.expirations.Add(1)
+		} else {
+			c.evictions.Add(1)
+		}
+	}
+}
+
+// Delete 删除缓存
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.removeEntry(e)
+	}
+}
+
+// Clear 清空所有条目
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range c.items {
+		c.removeEntry(e)
+	}
+}
+
+// Stats 返回统计信息
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	entries, bytes := len(c.items), c.usedBytes
+	c.mu.Unlock()
+	return Stats{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Evictions:   c.evictions.Load(),
+		Expirations: c.expirations.Load(),
+		Entries:     entries,
+		Bytes:       bytes,
+	}
+}
+
+// removeEntry 删除条目，调用方需持有锁
+func (c *Cache) removeEntry(e *entry) {
+	delete(c.items, e.key)
+	c.usedBytes -= e.size
+	if !e.pinned {
+		c.evictor.remove(e)
+	}
+}
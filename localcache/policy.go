@@ -0,0 +1,98 @@
+package localcache
+
+import (
+	"container/heap"
+	"container/list"
+)
+
+// lru 使用双向链表实现，表头为最近访问的条目，表尾为最久未访问的条目
+type lru struct {
+	ll *list.List
+}
+
+func newLRU() *lru {
+	return &lru{ll: list.New()}
+}
+
+func (p *lru) add(e *entry) {
+	e.elem = p.ll.PushFront(e)
+}
+
+func (p *lru) touch(e *entry) {
+	p.ll.MoveToFront(e.elem.(*list.Element))
+}
+
+func (p *lru) remove(e *entry) {
+	p.ll.Remove(e.elem.(*list.Element))
+}
+
+func (p *lru) victim() *entry {
+	back := p.ll.Back()
+	if back == nil {
+		return nil
+	}
+	return back.Value.(*entry)
+}
+
+// lfu 使用最小堆实现，堆顶为访问次数最少的条目，次数相同时为更久未访问的条目
+type lfu struct {
+	h entryHeap
+}
+
+func newLFU() *lfu {
+	return &lfu{}
+}
+
+func (p *lfu) add(e *entry) {
+	e.freq = 1
+	heap.Push(&p.h, e)
+}
+
+func (p *lfu) touch(e *entry) {
+	e.freq++
+	heap.Fix(&p.h, e.index)
+}
+
+func (p *lfu) remove(e *entry) {
+	heap.Remove(&p.h, e.index)
+}
+
+func (p *lfu) victim() *entry {
+	if len(p.h) == 0 {
+		return nil
+	}
+	return p.h[0]
+}
+
+// entryHeap 实现 heap.Interface
+type entryHeap []*entry
+
+func (h entryHeap) Len() int { return len(h) }
+
+func (h entryHeap) Less(i, j int) bool {
+	if h[i].freq != h[j].freq {
+		return h[i].freq < h[j].freq
+	}
+	return h[i].accessedAt.Before(h[j].accessedAt)
+}
+
+func (h entryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
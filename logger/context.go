@@ -0,0 +1,26 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// 请求级别的日志
+// 拦截器为每个请求创建带有请求 ID、方法名等字段的 logger 并放入 context，
+// biz 和 dao 中通过 FromContext 取出，同一请求的日志可以按请求 ID 串起来。
+
+type loggerKey struct{}
+
+// WithContext 返回携带 logger 的 context
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext 返回 context 中的 logger，没有时返回全局 logger
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return zap.L()
+}
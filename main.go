@@ -4,12 +4,17 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	bloomfilter "goods_srv/BloomFilter"
+	"goods_srv/biz/goods"
+	"goods_srv/binlog"
+	"goods_srv/bloomfilter"
+	"goods_srv/cachebus"
 	"goods_srv/config"
 	"goods_srv/dao/mysql"
 	"goods_srv/dao/redis"
 	"goods_srv/handler"
+	"goods_srv/interceptors"
 	"goods_srv/logger"
+	"goods_srv/metrics"
 	"goods_srv/proto"
 	"goods_srv/registry"
 	"net"
@@ -59,12 +64,34 @@ func main() {
 	if err != nil {
 		panic(err) // 如果初始化 Redis 失败，直接退出程序
 	}
-	//5.初始化布隆过滤器并启动
+	//5.初始化布隆过滤器并启动，新商品入库时清理其空值缓存
+	bloomfilter.OnNewGoods(goods.InvalidateGoodsCache)
 	err = bloomfilter.InitBloomFilter(ctx)
 	if err != nil {
 		panic(err)
 	}
 
+	// 6.初始化本地缓存、热点探测和监控指标服务
+	goods.InitLocalCache(config.Conf.LocalCacheConfig)
+	goods.InitHotKey(ctx, config.Conf.HotKeyConfig)
+	metrics.Init(config.Conf.HttpPort)
+
+	// 7.订阅缓存失效消息，其他实例更新商品后删除本实例的本地缓存，新增商品后加入本实例的布隆过滤器
+	serviceId := fmt.Sprintf("%s-%s-%d", config.Conf.Name, config.Conf.IP, config.Conf.Port)
+	cachebus.Start(ctx, serviceId, goods.EvictLocalCache, goods.ResetLocalCache, bloomfilter.AddNewGoods)
+
+	// 8.订阅 binlog，数据库被直接修改时删除对应缓存
+	binlog.Start(ctx, goods.BinlogHandler{})
+
+	// 9.预热正在直播的直播间商品缓存，预热完成后再注册服务接收流量
+	goods.InitWarmUp(ctx, config.Conf.WarmUpConfig)
+
+	// 10.启动定时改价调度
+	goods.InitPriceScheduler(ctx, config.Conf.PriceScheduleConfig)
+
+	// 11.订阅直播间商品事件，推送给订阅了直播间的客户端
+	goods.InitRoomWatch(ctx, config.Conf.RoomWatchConfig)
+
 	err = registry.Init(config.Conf.ConsulConfig.Addr)
 	if err != nil {
 		zap.L().Error("Failed to initialize Consul", zap.Error(err))
@@ -78,8 +105,19 @@ func main() {
 		panic(err)
 	}
 
-	// 创建 gRPC 服务
-	s := grpc.NewServer()
+	// 创建 gRPC 服务，注册拦截器：请求 ID、访问日志、panic 恢复
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptors.UnaryRequestID,
+			interceptors.UnaryAccessLog,
+			interceptors.UnaryRecovery,
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.StreamRequestID,
+			interceptors.StreamAccessLog,
+			interceptors.StreamRecovery,
+		),
+	)
 	// 注册健康检查服务
 	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
 	// 注册股票服务到 gRPC 服务
@@ -108,11 +146,15 @@ func main() {
 	)
 
 	// 服务退出时注销服务
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 	<-quit // 等待退出信号
 
+	// 保存布隆过滤器快照，下次启动时快速恢复
+	if err := bloomfilter.SaveSnapshot(); err != nil {
+		zap.L().Error("Failed to save bloom filter snapshot", zap.Error(err))
+	}
+
 	// 注销服务
-	serviceId := fmt.Sprintf("%s-%s-%d", config.Conf.Name, config.Conf.IP, config.Conf.Port)
 	registry.Reg.Deregister(serviceId)
 }
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// 服务监控指标，通过 HTTP 端口的 /metrics 暴露给 Prometheus 采集
+
+// Namespace 所有指标统一的前缀
+const Namespace = "goods_srv"
+
+// Init 启动 /metrics HTTP 服务
+func Init(port int) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
+		if err != nil {
+			zap.L().Error("metrics server stopped", zap.Error(err))
+		}
+	}()
+}
+
+// MustRegister 注册监控指标，重复注册时 panic
+func MustRegister(cs ...prometheus.Collector) {
+	prometheus.MustRegister(cs...)
+}
@@ -12,6 +12,6 @@ type BaseModel struct {
 	UpdateAt time.Time                // 更新时间
 	CreateBy string                   // 创建者
 	UpdateBy string                   // 更新者
-	Version  int16                    // 乐观锁版本号
-	isDel    int8 `gorm:"index"`      // 软删除标志
+	Version  uint16                   // 乐观锁版本号，与 SMALLINT UNSIGNED 列一致，达到 65535 后回绕到 0
+	IsDel    int8 `gorm:"index"`      // 软删除标志
 }
\ No newline at end of file
@@ -1,5 +1,29 @@
 package model
 
+import "slices"
+
+// 商品状态，与 proto.GoodsStatus 的取值一致
+const (
+	GoodsStatusOnShelf     int8 = 0 // 上架
+	GoodsStatusOffShelf    int8 = 1 // 下架
+	GoodsStatusDraft       int8 = 2 // 草稿
+	GoodsStatusUnderReview int8 = 3 // 审核中
+	GoodsStatusDeleted     int8 = 4 // 已删除
+)
+
+// goodsStatusTransitions 商品状态允许的流转，已删除的商品不能再变更
+var goodsStatusTransitions = map[int8][]int8{
+	GoodsStatusDraft:       {GoodsStatusUnderReview, GoodsStatusDeleted}, // 草稿提交审核或直接删除
+	GoodsStatusUnderReview: {GoodsStatusOnShelf, GoodsStatusDraft},       // 审核通过上架，驳回退回草稿
+	GoodsStatusOnShelf:     {GoodsStatusOffShelf},                        // 上架的商品必须先下架
+	GoodsStatusOffShelf:    {GoodsStatusOnShelf, GoodsStatusDraft, GoodsStatusDeleted},
+}
+
+// CanChangeGoodsStatus 判断商品状态是否允许从 from 变更为 to
+func CanChangeGoodsStatus(from, to int8) bool {
+	return slices.Contains(goodsStatusTransitions[from], to)
+}
+
 // ORM
 // struct -> table
 // Goods 商品模型
@@ -10,7 +34,7 @@ type Goods struct {
 	CategoryId  int64  `gorm:"notNull"`             // 商品所属分类ID
 	BrandName   string `gorm:"notNull"`             // 品牌名称
 	Code        string `gorm:"notNull;uniqueIndex"` // 商品编码，唯一标识一个商品
-	Status      int8   `gorm:"notNull"`             // 商品状态（例如：上架、下架、审核中等）
+	Status      int8   `gorm:"notNull"`             // 商品状态：0上架1下架2草稿3审核中4已删除
 	Title       string `gorm:"notNull"`             // 商品标题
 	MarketPrice int64  `gorm:"notNull"`             // 市场价
 	Price       int64  `gorm:"notNull"`             // 实际销售价格
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// 商品修改日志的操作类型
+const (
+	ChangeActionCreate = "create" // 新增商品
+	ChangeActionUpdate = "update" // 修改商品
+	ChangeActionDelete = "delete" // 删除商品
+)
+
+// GoodsChangeLog 商品修改日志模型，每次修改商品记录一行
+type GoodsChangeLog struct {
+	ID         uint      `gorm:"primaryKey"`        // 主键ID
+	GoodsId    int64     `gorm:"notNull;index"`     // 商品ID
+	Action     string    `gorm:"notNull"`           // 操作类型：create / update / delete
+	OldValue   string    `gorm:"type:text"`         // 修改前的字段值（JSON）
+	NewValue   string    `gorm:"type:text"`         // 修改后的字段值（JSON）
+	Operator   string    `gorm:"notNull"`           // 操作人
+	Reason     string    `gorm:"notNull"`           // 修改原因
+	ScheduleId uint      `gorm:"notNull;default:0"` // 产生该修改的定时改价ID，0 表示不是定时改价
+	CreateAt   time.Time // 修改时间
+}
+
+// TableName 定义表名
+func (GoodsChangeLog) TableName() string {
+	return "xx_goods_change_log"
+}
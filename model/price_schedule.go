@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// 定时改价的状态
+const (
+	PriceSchedulePending  int8 = 0 // 待生效
+	PriceScheduleActive   int8 = 1 // 生效中
+	PriceScheduleFinished int8 = 2 // 已结束，价格已恢复；没有结束时间的定时改价生效后即为已结束
+	PriceScheduleCanceled int8 = 3 // 已取消，例如商品已被删除
+)
+
+// PriceSchedule 定时改价模型，在 EffectiveFrom 时将商品改为 Price，在 EffectiveTo 时恢复原价
+type PriceSchedule struct {
+	ID            uint       `gorm:"primaryKey"`    // 主键ID
+	GoodsId       int64      `gorm:"notNull;index"` // 商品ID
+	Price         int64      `gorm:"notNull"`       // 生效期间的售价（分）
+	OriginalPrice int64      `gorm:"notNull"`       // 生效时的原售价（分），结束时恢复
+	EffectiveFrom time.Time  `gorm:"notNull"`       // 生效时间
+	EffectiveTo   *time.Time // 结束时间，为空表示生效后不再恢复
+	Status        int8       `gorm:"notNull;index"` // 状态：0待生效1生效中2已结束3已取消
+	Operator      string     `gorm:"notNull"`       // 创建人
+	Reason        string     `gorm:"notNull"`       // 改价原因
+	CreateAt      time.Time  // 创建时间
+	UpdateAt      time.Time  // 更新时间
+}
+
+// TableName 定义表名
+func (PriceSchedule) TableName() string {
+	return "xx_goods_price_schedule"
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,6 +22,124 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 商品状态，取值与 xx_goods_query 表的 status 字段一致，只有上架的商品可以售卖
+type GoodsStatus int32
+
+const (
+	GoodsStatus_GOODS_STATUS_ON_SHELF     GoodsStatus = 0 // 上架
+	GoodsStatus_GOODS_STATUS_OFF_SHELF    GoodsStatus = 1 // 下架
+	GoodsStatus_GOODS_STATUS_DRAFT        GoodsStatus = 2 // 草稿
+	GoodsStatus_GOODS_STATUS_UNDER_REVIEW GoodsStatus = 3 // 审核中
+	GoodsStatus_GOODS_STATUS_DELETED      GoodsStatus = 4 // 已删除
+)
+
+// Enum value maps for GoodsStatus.
+var (
+	GoodsStatus_name = map[int32]string{
+		0: "GOODS_STATUS_ON_SHELF",
+		1: "GOODS_STATUS_OFF_SHELF",
+		2: "GOODS_STATUS_DRAFT",
+		3: "GOODS_STATUS_UNDER_REVIEW",
+		4: "GOODS_STATUS_DELETED",
+	}
+	GoodsStatus_value = map[string]int32{
+		"GOODS_STATUS_ON_SHELF":     0,
+		"GOODS_STATUS_OFF_SHELF":    1,
+		"GOODS_STATUS_DRAFT":        2,
+		"GOODS_STATUS_UNDER_REVIEW": 3,
+		"GOODS_STATUS_DELETED":      4,
+	}
+)
+
+func (x GoodsStatus) Enum() *GoodsStatus {
+	p := new(GoodsStatus)
+	*p = x
+	return p
+}
+
+func (x GoodsStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GoodsStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_goods_proto_enumTypes[0].Descriptor()
+}
+
+func (GoodsStatus) Type() protoreflect.EnumType {
+	return &file_goods_proto_enumTypes[0]
+}
+
+func (x GoodsStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GoodsStatus.Descriptor instead.
+func (GoodsStatus) EnumDescriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{0}
+}
+
+// 直播间商品事件类型
+type RoomGoodsEventType int32
+
+const (
+	RoomGoodsEventType_EVENT_UNKNOWN         RoomGoodsEventType = 0 // 未知事件
+	RoomGoodsEventType_EVENT_SNAPSHOT        RoomGoodsEventType = 1 // 商品列表快照
+	RoomGoodsEventType_EVENT_CURRENT_CHANGED RoomGoodsEventType = 2 // 切换了当前讲解的商品
+	RoomGoodsEventType_EVENT_GOODS_ADDED     RoomGoodsEventType = 3 // 直播间新增商品
+	RoomGoodsEventType_EVENT_GOODS_REMOVED   RoomGoodsEventType = 4 // 直播间移除商品
+	RoomGoodsEventType_EVENT_GOODS_REORDERED RoomGoodsEventType = 5 // 直播间商品重新排序
+	RoomGoodsEventType_EVENT_PRICE_CHANGED   RoomGoodsEventType = 6 // 商品价格变化
+)
+
+// Enum value maps for RoomGoodsEventType.
+var (
+	RoomGoodsEventType_name = map[int32]string{
+		0: "EVENT_UNKNOWN",
+		1: "EVENT_SNAPSHOT",
+		2: "EVENT_CURRENT_CHANGED",
+		3: "EVENT_GOODS_ADDED",
+		4: "EVENT_GOODS_REMOVED",
+		5: "EVENT_GOODS_REORDERED",
+		6: "EVENT_PRICE_CHANGED",
+	}
+	RoomGoodsEventType_value = map[string]int32{
+		"EVENT_UNKNOWN":         0,
+		"EVENT_SNAPSHOT":        1,
+		"EVENT_CURRENT_CHANGED": 2,
+		"EVENT_GOODS_ADDED":     3,
+		"EVENT_GOODS_REMOVED":   4,
+		"EVENT_GOODS_REORDERED": 5,
+		"EVENT_PRICE_CHANGED":   6,
+	}
+)
+
+func (x RoomGoodsEventType) Enum() *RoomGoodsEventType {
+	p := new(RoomGoodsEventType)
+	*p = x
+	return p
+}
+
+func (x RoomGoodsEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoomGoodsEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_goods_proto_enumTypes[1].Descriptor()
+}
+
+func (RoomGoodsEventType) Type() protoreflect.EnumType {
+	return &file_goods_proto_enumTypes[1]
+}
+
+func (x RoomGoodsEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoomGoodsEventType.Descriptor instead.
+func (RoomGoodsEventType) EnumDescriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{1}
+}
+
 // 响应消息结构
 type Response struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -76,11 +195,12 @@ func (x *Response) GetMessage() string {
 
 // 定义请求消息 GetGoodsByRoomReq，用于获取直播间商品列表
 type GetGoodsByRoomReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=UserId,proto3" json:"UserId,omitempty"` // 用户 ID
-	RoomId        int64                  `protobuf:"varint,2,opt,name=RoomId,proto3" json:"RoomId,omitempty"` // 直播间 ID
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            int64                  `protobuf:"varint,1,opt,name=UserId,proto3" json:"UserId,omitempty"`                       // 用户 ID
+	RoomId            int64                  `protobuf:"varint,2,opt,name=RoomId,proto3" json:"RoomId,omitempty"`                       // 直播间 ID
+	IncludeUnsellable bool                   `protobuf:"varint,3,opt,name=IncludeUnsellable,proto3" json:"IncludeUnsellable,omitempty"` // 是否包含不可售（草稿、下架、审核中）的商品，管理后台使用，默认只返回上架的商品
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetGoodsByRoomReq) Reset() {
@@ -127,11 +247,19 @@ func (x *GetGoodsByRoomReq) GetRoomId() int64 {
 	return 0
 }
 
+func (x *GetGoodsByRoomReq) GetIncludeUnsellable() bool {
+	if x != nil {
+		return x.IncludeUnsellable
+	}
+	return false
+}
+
 // 定义响应消息 GoodsListResp，用于返回商品列表
 type GoodsListResp struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	CurrentGoodsId int64                  `protobuf:"varint,1,opt,name=CurrentGoodsId,proto3" json:"CurrentGoodsId,omitempty"` // 当前商品的 ID
 	Data           []*GoodsInfo           `protobuf:"bytes,2,rep,name=Data,proto3" json:"Data,omitempty"`                      // 商品列表，包含多个 GoodsInfo 消息
+	Stale          bool                   `protobuf:"varint,3,opt,name=Stale,proto3" json:"Stale,omitempty"`                   // 是否为降级返回的旧数据
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -180,16 +308,23 @@ func (x *GoodsListResp) GetData() []*GoodsInfo {
 	return nil
 }
 
+func (x *GoodsListResp) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
 // 定义商品列表页的数据结构 GoodsInfo
 type GoodsInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`        // 商品 ID
-	CategoryId    int64                  `protobuf:"varint,2,opt,name=CategoryId,proto3" json:"CategoryId,omitempty"`  // 分类 ID
-	Status        int32                  `protobuf:"varint,3,opt,name=Status,proto3" json:"Status,omitempty"`          // 商品状态
-	Title         string                 `protobuf:"bytes,4,opt,name=Title,proto3" json:"Title,omitempty"`             // 商品标题
-	MarketPrice   string                 `protobuf:"bytes,5,opt,name=MarketPrice,proto3" json:"MarketPrice,omitempty"` // 市场价格
-	Price         string                 `protobuf:"bytes,6,opt,name=Price,proto3" json:"Price,omitempty"`             // 销售价格
-	Brief         string                 `protobuf:"bytes,7,opt,name=Brief,proto3" json:"Brief,omitempty"`             // 商品简介
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`                      // 商品 ID
+	CategoryId    int64                  `protobuf:"varint,2,opt,name=CategoryId,proto3" json:"CategoryId,omitempty"`                // 分类 ID
+	Status        GoodsStatus            `protobuf:"varint,3,opt,name=Status,proto3,enum=proto.GoodsStatus" json:"Status,omitempty"` // 商品状态
+	Title         string                 `protobuf:"bytes,4,opt,name=Title,proto3" json:"Title,omitempty"`                           // 商品标题
+	MarketPrice   string                 `protobuf:"bytes,5,opt,name=MarketPrice,proto3" json:"MarketPrice,omitempty"`               // 市场价格
+	Price         string                 `protobuf:"bytes,6,opt,name=Price,proto3" json:"Price,omitempty"`                           // 销售价格
+	Brief         string                 `protobuf:"bytes,7,opt,name=Brief,proto3" json:"Brief,omitempty"`                           // 商品简介
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -238,11 +373,11 @@ func (x *GoodsInfo) GetCategoryId() int64 {
 	return 0
 }
 
-func (x *GoodsInfo) GetStatus() int32 {
+func (x *GoodsInfo) GetStatus() GoodsStatus {
 	if x != nil {
 		return x.Status
 	}
-	return 0
+	return GoodsStatus_GOODS_STATUS_ON_SHELF
 }
 
 func (x *GoodsInfo) GetTitle() string {
@@ -273,29 +408,30 @@ func (x *GoodsInfo) GetBrief() string {
 	return ""
 }
 
-// 定义请求消息 GetGoodsDetailReq，用于获取商品详情
-type GetGoodsDetailReq struct {
+// 定义请求消息 BindGoodsToRoomReq，用于将商品绑定到直播间
+type BindGoodsToRoomReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 商品 ID
-	UserId        int64                  `protobuf:"varint,2,opt,name=UserId,proto3" json:"UserId,omitempty"`   // 用户 ID
+	RoomId        int64                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"`   // 直播间 ID
+	GoodsId       int64                  `protobuf:"varint,2,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 商品 ID
+	Weight        int64                  `protobuf:"varint,3,opt,name=Weight,proto3" json:"Weight,omitempty"`   // 排序权重，越小越靠前，0 表示使用默认权重
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetGoodsDetailReq) Reset() {
-	*x = GetGoodsDetailReq{}
+func (x *BindGoodsToRoomReq) Reset() {
+	*x = BindGoodsToRoomReq{}
 	mi := &file_goods_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetGoodsDetailReq) String() string {
+func (x *BindGoodsToRoomReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGoodsDetailReq) ProtoMessage() {}
+func (*BindGoodsToRoomReq) ProtoMessage() {}
 
-func (x *GetGoodsDetailReq) ProtoReflect() protoreflect.Message {
+func (x *BindGoodsToRoomReq) ProtoReflect() protoreflect.Message {
 	mi := &file_goods_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -307,48 +443,55 @@ func (x *GetGoodsDetailReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGoodsDetailReq.ProtoReflect.Descriptor instead.
-func (*GetGoodsDetailReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use BindGoodsToRoomReq.ProtoReflect.Descriptor instead.
+func (*BindGoodsToRoomReq) Descriptor() ([]byte, []int) {
 	return file_goods_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetGoodsDetailReq) GetGoodsId() int64 {
+func (x *BindGoodsToRoomReq) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *BindGoodsToRoomReq) GetGoodsId() int64 {
 	if x != nil {
 		return x.GoodsId
 	}
 	return 0
 }
 
-func (x *GetGoodsDetailReq) GetUserId() int64 {
+func (x *BindGoodsToRoomReq) GetWeight() int64 {
 	if x != nil {
-		return x.UserId
+		return x.Weight
 	}
 	return 0
 }
 
-// 定义请求消息 UpdateGoodsDetailReq，用于获取商品详情
-type UpdateGoodsDetailReq struct {
+// 定义请求消息 UnbindGoodsFromRoomReq，用于解绑直播间的商品
+type UnbindGoodsFromRoomReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 商品 ID
-	Price         int64                  `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`     //更新后商品的销售价格
+	RoomId        int64                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"`   // 直播间 ID
+	GoodsId       int64                  `protobuf:"varint,2,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 商品 ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateGoodsDetailReq) Reset() {
-	*x = UpdateGoodsDetailReq{}
+func (x *UnbindGoodsFromRoomReq) Reset() {
+	*x = UnbindGoodsFromRoomReq{}
 	mi := &file_goods_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateGoodsDetailReq) String() string {
+func (x *UnbindGoodsFromRoomReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateGoodsDetailReq) ProtoMessage() {}
+func (*UnbindGoodsFromRoomReq) ProtoMessage() {}
 
-func (x *UpdateGoodsDetailReq) ProtoReflect() protoreflect.Message {
+func (x *UnbindGoodsFromRoomReq) ProtoReflect() protoreflect.Message {
 	mi := &file_goods_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -360,55 +503,48 @@ func (x *UpdateGoodsDetailReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateGoodsDetailReq.ProtoReflect.Descriptor instead.
-func (*UpdateGoodsDetailReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use UnbindGoodsFromRoomReq.ProtoReflect.Descriptor instead.
+func (*UnbindGoodsFromRoomReq) Descriptor() ([]byte, []int) {
 	return file_goods_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *UpdateGoodsDetailReq) GetGoodsId() int64 {
+func (x *UnbindGoodsFromRoomReq) GetRoomId() int64 {
 	if x != nil {
-		return x.GoodsId
+		return x.RoomId
 	}
 	return 0
 }
 
-func (x *UpdateGoodsDetailReq) GetPrice() int64 {
+func (x *UnbindGoodsFromRoomReq) GetGoodsId() int64 {
 	if x != nil {
-		return x.Price
+		return x.GoodsId
 	}
 	return 0
 }
 
-// 定义响应消息 GoodsDetail，用于返回商品详情
-type GoodsDetail struct {
+// 定义请求消息 ReorderRoomGoodsReq，用于重新排列直播间的商品
+type ReorderRoomGoodsReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`        // 商品 ID
-	CategoryId    int64                  `protobuf:"varint,2,opt,name=CategoryId,proto3" json:"CategoryId,omitempty"`  // 分类 ID
-	Status        int32                  `protobuf:"varint,3,opt,name=Status,proto3" json:"Status,omitempty"`          // 商品状态
-	Title         string                 `protobuf:"bytes,4,opt,name=Title,proto3" json:"Title,omitempty"`             // 商品标题
-	Code          string                 `protobuf:"bytes,5,opt,name=Code,proto3" json:"Code,omitempty"`               // 商品编码
-	BrandName     string                 `protobuf:"bytes,6,opt,name=BrandName,proto3" json:"BrandName,omitempty"`     // 品牌名称
-	MarketPrice   string                 `protobuf:"bytes,7,opt,name=MarketPrice,proto3" json:"MarketPrice,omitempty"` // 市场价格
-	Price         string                 `protobuf:"bytes,8,opt,name=Price,proto3" json:"Price,omitempty"`             // 销售价格
-	Brief         string                 `protobuf:"bytes,9,opt,name=Brief,proto3" json:"Brief,omitempty"`             // 商品简介
+	RoomId        int64                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"`            // 直播间 ID
+	GoodsIds      []int64                `protobuf:"varint,2,rep,packed,name=GoodsIds,proto3" json:"GoodsIds,omitempty"` // 按新顺序排列的商品 ID，必须包含直播间绑定的所有商品
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GoodsDetail) Reset() {
-	*x = GoodsDetail{}
+func (x *ReorderRoomGoodsReq) Reset() {
+	*x = ReorderRoomGoodsReq{}
 	mi := &file_goods_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GoodsDetail) String() string {
+func (x *ReorderRoomGoodsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GoodsDetail) ProtoMessage() {}
+func (*ReorderRoomGoodsReq) ProtoMessage() {}
 
-func (x *GoodsDetail) ProtoReflect() protoreflect.Message {
+func (x *ReorderRoomGoodsReq) ProtoReflect() protoreflect.Message {
 	mi := &file_goods_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -420,144 +556,2216 @@ func (x *GoodsDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GoodsDetail.ProtoReflect.Descriptor instead.
-func (*GoodsDetail) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReorderRoomGoodsReq.ProtoReflect.Descriptor instead.
+func (*ReorderRoomGoodsReq) Descriptor() ([]byte, []int) {
 	return file_goods_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GoodsDetail) GetGoodsId() int64 {
+func (x *ReorderRoomGoodsReq) GetRoomId() int64 {
 	if x != nil {
-		return x.GoodsId
+		return x.RoomId
 	}
 	return 0
 }
 
-func (x *GoodsDetail) GetCategoryId() int64 {
+func (x *ReorderRoomGoodsReq) GetGoodsIds() []int64 {
 	if x != nil {
-		return x.CategoryId
+		return x.GoodsIds
+	}
+	return nil
+}
+
+// 定义请求消息 SetCurrentGoodsReq，用于切换直播间当前讲解的商品
+type SetCurrentGoodsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"`   // 直播间 ID
+	GoodsId       int64                  `protobuf:"varint,2,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 当前讲解的商品 ID，0 表示清除
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCurrentGoodsReq) Reset() {
+	*x = SetCurrentGoodsReq{}
+	mi := &file_goods_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCurrentGoodsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCurrentGoodsReq) ProtoMessage() {}
+
+func (x *SetCurrentGoodsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCurrentGoodsReq.ProtoReflect.Descriptor instead.
+func (*SetCurrentGoodsReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetCurrentGoodsReq) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
 	}
 	return 0
 }
 
-func (x *GoodsDetail) GetStatus() int32 {
+func (x *SetCurrentGoodsReq) GetGoodsId() int64 {
 	if x != nil {
-		return x.Status
+		return x.GoodsId
 	}
 	return 0
 }
 
-func (x *GoodsDetail) GetTitle() string {
+// 定义请求消息 WatchRoomGoodsReq，用于订阅直播间商品变化
+type WatchRoomGoodsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"`          // 直播间 ID
+	ResumeToken   string                 `protobuf:"bytes,2,opt,name=ResumeToken,proto3" json:"ResumeToken,omitempty"` // 断线重连时传入最后收到的事件的 ResumeToken，从该事件之后继续推送
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRoomGoodsReq) Reset() {
+	*x = WatchRoomGoodsReq{}
+	mi := &file_goods_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRoomGoodsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRoomGoodsReq) ProtoMessage() {}
+
+func (x *WatchRoomGoodsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[8]
 	if x != nil {
-		return x.Title
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *GoodsDetail) GetCode() string {
+// Deprecated: Use WatchRoomGoodsReq.ProtoReflect.Descriptor instead.
+func (*WatchRoomGoodsReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchRoomGoodsReq) GetRoomId() int64 {
 	if x != nil {
-		return x.Code
+		return x.RoomId
 	}
-	return ""
+	return 0
 }
 
-func (x *GoodsDetail) GetBrandName() string {
+func (x *WatchRoomGoodsReq) GetResumeToken() string {
 	if x != nil {
-		return x.BrandName
+		return x.ResumeToken
 	}
 	return ""
 }
 
-func (x *GoodsDetail) GetMarketPrice() string {
+// 定义直播间商品事件 RoomGoodsEvent
+type RoomGoodsEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          RoomGoodsEventType     `protobuf:"varint,1,opt,name=Type,proto3,enum=proto.RoomGoodsEventType" json:"Type,omitempty"` // 事件类型
+	ResumeToken   string                 `protobuf:"bytes,2,opt,name=ResumeToken,proto3" json:"ResumeToken,omitempty"`                  // 断线重连时使用的位置
+	RoomId        int64                  `protobuf:"varint,3,opt,name=RoomId,proto3" json:"RoomId,omitempty"`                           // 直播间 ID
+	GoodsId       int64                  `protobuf:"varint,4,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`                         // 相关的商品 ID，快照和重新排序事件为 0
+	Price         string                 `protobuf:"bytes,5,opt,name=Price,proto3" json:"Price,omitempty"`                              // 变化后的销售价格，只有价格变化事件有值
+	Snapshot      *GoodsListResp         `protobuf:"bytes,6,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"`                        // 商品列表快照，只有快照事件有值
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomGoodsEvent) Reset() {
+	*x = RoomGoodsEvent{}
+	mi := &file_goods_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomGoodsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomGoodsEvent) ProtoMessage() {}
+
+func (x *RoomGoodsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[9]
 	if x != nil {
-		return x.MarketPrice
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomGoodsEvent.ProtoReflect.Descriptor instead.
+func (*RoomGoodsEvent) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RoomGoodsEvent) GetType() RoomGoodsEventType {
+	if x != nil {
+		return x.Type
+	}
+	return RoomGoodsEventType_EVENT_UNKNOWN
+}
+
+func (x *RoomGoodsEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
 	}
 	return ""
 }
 
-func (x *GoodsDetail) GetPrice() string {
+func (x *RoomGoodsEvent) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *RoomGoodsEvent) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *RoomGoodsEvent) GetPrice() string {
 	if x != nil {
 		return x.Price
 	}
 	return ""
 }
 
-func (x *GoodsDetail) GetBrief() string {
+func (x *RoomGoodsEvent) GetSnapshot() *GoodsListResp {
 	if x != nil {
-		return x.Brief
+		return x.Snapshot
 	}
-	return ""
+	return nil
+}
+
+// 定义请求消息 GetGoodsDetailReq，用于获取商品详情
+type GetGoodsDetailReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 商品 ID
+	UserId        int64                  `protobuf:"varint,2,opt,name=UserId,proto3" json:"UserId,omitempty"`   // 用户 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGoodsDetailReq) Reset() {
+	*x = GetGoodsDetailReq{}
+	mi := &file_goods_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGoodsDetailReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGoodsDetailReq) ProtoMessage() {}
+
+func (x *GetGoodsDetailReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGoodsDetailReq.ProtoReflect.Descriptor instead.
+func (*GetGoodsDetailReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetGoodsDetailReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *GetGoodsDetailReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// 定义请求消息 UpdateGoodsReq，用于按字段掩码部分更新商品信息
+type UpdateGoodsReq struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Goods           *GoodsDetail           `protobuf:"bytes,1,opt,name=Goods,proto3" json:"Goods,omitempty"`                            // 商品信息，GoodsId 指定要更新的商品，其他字段只有在 UpdateMask 中时才生效
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=UpdateMask,proto3" json:"UpdateMask,omitempty"`                  // 要更新的字段，例如 ["Title", "Price"]
+	ExpectedVersion *int32                 `protobuf:"varint,3,opt,name=ExpectedVersion,proto3,oneof" json:"ExpectedVersion,omitempty"` // 期望的商品版本号，与数据库中的版本号不一致时更新失败，不传表示不检查
+	Reason          string                 `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`                          // 修改原因，记录在商品修改日志中
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateGoodsReq) Reset() {
+	*x = UpdateGoodsReq{}
+	mi := &file_goods_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGoodsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGoodsReq) ProtoMessage() {}
+
+func (x *UpdateGoodsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGoodsReq.ProtoReflect.Descriptor instead.
+func (*UpdateGoodsReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateGoodsReq) GetGoods() *GoodsDetail {
+	if x != nil {
+		return x.Goods
+	}
+	return nil
+}
+
+func (x *UpdateGoodsReq) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateGoodsReq) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateGoodsReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 定义请求消息 BatchGetGoodsDetailReq，用于批量获取商品详情
+type BatchGetGoodsDetailReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsIds      []int64                `protobuf:"varint,1,rep,packed,name=GoodsIds,proto3" json:"GoodsIds,omitempty"` // 商品 ID 列表
+	UserId        int64                  `protobuf:"varint,2,opt,name=UserId,proto3" json:"UserId,omitempty"`            // 用户 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetGoodsDetailReq) Reset() {
+	*x = BatchGetGoodsDetailReq{}
+	mi := &file_goods_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetGoodsDetailReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetGoodsDetailReq) ProtoMessage() {}
+
+func (x *BatchGetGoodsDetailReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetGoodsDetailReq.ProtoReflect.Descriptor instead.
+func (*BatchGetGoodsDetailReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchGetGoodsDetailReq) GetGoodsIds() []int64 {
+	if x != nil {
+		return x.GoodsIds
+	}
+	return nil
+}
+
+func (x *BatchGetGoodsDetailReq) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// 定义响应消息 BatchGoodsDetailResp，用于返回批量查询的商品详情
+type BatchGoodsDetailResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*GoodsDetailResult   `protobuf:"bytes,1,rep,name=Data,proto3" json:"Data,omitempty"`    // 每个商品的查询结果，与请求中的商品 ID 一一对应
+	Stale         bool                   `protobuf:"varint,2,opt,name=Stale,proto3" json:"Stale,omitempty"` // 是否包含降级返回的旧数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGoodsDetailResp) Reset() {
+	*x = BatchGoodsDetailResp{}
+	mi := &file_goods_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGoodsDetailResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGoodsDetailResp) ProtoMessage() {}
+
+func (x *BatchGoodsDetailResp) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGoodsDetailResp.ProtoReflect.Descriptor instead.
+func (*BatchGoodsDetailResp) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchGoodsDetailResp) GetData() []*GoodsDetailResult {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BatchGoodsDetailResp) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+// 定义单个商品的查询结果 GoodsDetailResult
+type GoodsDetailResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`         // 商品 ID
+	Detail        *GoodsDetail           `protobuf:"bytes,2,opt,name=Detail,proto3" json:"Detail,omitempty"`            // 商品详情，商品不存在时为空
+	NotFound      bool                   `protobuf:"varint,3,opt,name=NotFound,proto3" json:"NotFound,omitempty"`       // 商品是否不存在
+	Unavailable   bool                   `protobuf:"varint,4,opt,name=Unavailable,proto3" json:"Unavailable,omitempty"` // 存储故障且没有旧数据，暂时无法查询该商品，商品不一定不存在
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoodsDetailResult) Reset() {
+	*x = GoodsDetailResult{}
+	mi := &file_goods_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoodsDetailResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoodsDetailResult) ProtoMessage() {}
+
+func (x *GoodsDetailResult) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoodsDetailResult.ProtoReflect.Descriptor instead.
+func (*GoodsDetailResult) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GoodsDetailResult) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *GoodsDetailResult) GetDetail() *GoodsDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+func (x *GoodsDetailResult) GetNotFound() bool {
+	if x != nil {
+		return x.NotFound
+	}
+	return false
+}
+
+func (x *GoodsDetailResult) GetUnavailable() bool {
+	if x != nil {
+		return x.Unavailable
+	}
+	return false
+}
+
+// 定义请求消息 UpdateGoodsDetailReq，用于获取商品详情
+type UpdateGoodsDetailReq struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId         int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`                       // 商品 ID
+	Price           int64                  `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`                           //更新后商品的销售价格
+	ExpectedVersion *int32                 `protobuf:"varint,3,opt,name=ExpectedVersion,proto3,oneof" json:"ExpectedVersion,omitempty"` // 期望的商品版本号，与数据库中的版本号不一致时更新失败，不传表示不检查
+	Reason          string                 `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`                          // 修改原因，记录在商品修改日志中
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateGoodsDetailReq) Reset() {
+	*x = UpdateGoodsDetailReq{}
+	mi := &file_goods_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGoodsDetailReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGoodsDetailReq) ProtoMessage() {}
+
+func (x *UpdateGoodsDetailReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGoodsDetailReq.ProtoReflect.Descriptor instead.
+func (*UpdateGoodsDetailReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateGoodsDetailReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *UpdateGoodsDetailReq) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateGoodsDetailReq) GetExpectedVersion() int32 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateGoodsDetailReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 定义请求消息 CreateGoodsReq，用于新增商品
+type CreateGoodsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`                      // 商品 ID
+	CategoryId    int64                  `protobuf:"varint,2,opt,name=CategoryId,proto3" json:"CategoryId,omitempty"`                // 分类 ID
+	BrandName     string                 `protobuf:"bytes,3,opt,name=BrandName,proto3" json:"BrandName,omitempty"`                   // 品牌名称
+	Code          string                 `protobuf:"bytes,4,opt,name=Code,proto3" json:"Code,omitempty"`                             // 商品编码
+	Status        GoodsStatus            `protobuf:"varint,5,opt,name=Status,proto3,enum=proto.GoodsStatus" json:"Status,omitempty"` // 商品状态，不能为已删除
+	Title         string                 `protobuf:"bytes,6,opt,name=Title,proto3" json:"Title,omitempty"`                           // 商品标题
+	MarketPrice   int64                  `protobuf:"varint,7,opt,name=MarketPrice,proto3" json:"MarketPrice,omitempty"`              // 市场价格（分）
+	Price         int64                  `protobuf:"varint,8,opt,name=Price,proto3" json:"Price,omitempty"`                          // 销售价格（分）
+	Brief         string                 `protobuf:"bytes,9,opt,name=Brief,proto3" json:"Brief,omitempty"`                           // 商品简介
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGoodsReq) Reset() {
+	*x = CreateGoodsReq{}
+	mi := &file_goods_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGoodsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGoodsReq) ProtoMessage() {}
+
+func (x *CreateGoodsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGoodsReq.ProtoReflect.Descriptor instead.
+func (*CreateGoodsReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateGoodsReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *CreateGoodsReq) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CreateGoodsReq) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+func (x *CreateGoodsReq) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateGoodsReq) GetStatus() GoodsStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GoodsStatus_GOODS_STATUS_ON_SHELF
+}
+
+func (x *CreateGoodsReq) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateGoodsReq) GetMarketPrice() int64 {
+	if x != nil {
+		return x.MarketPrice
+	}
+	return 0
+}
+
+func (x *CreateGoodsReq) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateGoodsReq) GetBrief() string {
+	if x != nil {
+		return x.Brief
+	}
+	return ""
+}
+
+// 定义请求消息 DeleteGoodsReq，用于删除商品
+type DeleteGoodsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 商品 ID
+	Reason        string                 `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`    // 删除原因，记录在商品修改日志中
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGoodsReq) Reset() {
+	*x = DeleteGoodsReq{}
+	mi := &file_goods_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGoodsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGoodsReq) ProtoMessage() {}
+
+func (x *DeleteGoodsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGoodsReq.ProtoReflect.Descriptor instead.
+func (*DeleteGoodsReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteGoodsReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *DeleteGoodsReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 定义请求消息 ChangeGoodsStatusReq，用于修改商品状态
+type ChangeGoodsStatusReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`                      // 商品 ID
+	Status        GoodsStatus            `protobuf:"varint,2,opt,name=Status,proto3,enum=proto.GoodsStatus" json:"Status,omitempty"` // 目标状态
+	Reason        string                 `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`                         // 修改原因，记录在商品修改日志中
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeGoodsStatusReq) Reset() {
+	*x = ChangeGoodsStatusReq{}
+	mi := &file_goods_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeGoodsStatusReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeGoodsStatusReq) ProtoMessage() {}
+
+func (x *ChangeGoodsStatusReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeGoodsStatusReq.ProtoReflect.Descriptor instead.
+func (*ChangeGoodsStatusReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ChangeGoodsStatusReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *ChangeGoodsStatusReq) GetStatus() GoodsStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GoodsStatus_GOODS_STATUS_ON_SHELF
+}
+
+func (x *ChangeGoodsStatusReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 定义请求消息 ListGoodsReq，用于按条件分页查询商品列表
+type ListGoodsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=CategoryId,proto3" json:"CategoryId,omitempty"`                      // 分类 ID，0 表示不过滤
+	BrandName     string                 `protobuf:"bytes,2,opt,name=BrandName,proto3" json:"BrandName,omitempty"`                         // 品牌名称，为空表示不过滤
+	Status        *GoodsStatus           `protobuf:"varint,3,opt,name=Status,proto3,enum=proto.GoodsStatus,oneof" json:"Status,omitempty"` // 商品状态，不传表示不过滤
+	Keyword       string                 `protobuf:"bytes,4,opt,name=Keyword,proto3" json:"Keyword,omitempty"`                             // 商品标题关键字
+	Page          int32                  `protobuf:"varint,5,opt,name=Page,proto3" json:"Page,omitempty"`                                  // 页码，从 1 开始
+	PageSize      int32                  `protobuf:"varint,6,opt,name=PageSize,proto3" json:"PageSize,omitempty"`                          // 每页条数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGoodsReq) Reset() {
+	*x = ListGoodsReq{}
+	mi := &file_goods_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGoodsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGoodsReq) ProtoMessage() {}
+
+func (x *ListGoodsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGoodsReq.ProtoReflect.Descriptor instead.
+func (*ListGoodsReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListGoodsReq) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *ListGoodsReq) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+func (x *ListGoodsReq) GetStatus() GoodsStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return GoodsStatus_GOODS_STATUS_ON_SHELF
+}
+
+func (x *ListGoodsReq) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *ListGoodsReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListGoodsReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 定义响应消息 ListGoodsResp，用于返回商品列表
+type ListGoodsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=Total,proto3" json:"Total,omitempty"` // 符合条件的商品总数
+	Data          []*GoodsDetail         `protobuf:"bytes,2,rep,name=Data,proto3" json:"Data,omitempty"`    // 当前页的商品列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGoodsResp) Reset() {
+	*x = ListGoodsResp{}
+	mi := &file_goods_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGoodsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGoodsResp) ProtoMessage() {}
+
+func (x *ListGoodsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGoodsResp.ProtoReflect.Descriptor instead.
+func (*ListGoodsResp) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListGoodsResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListGoodsResp) GetData() []*GoodsDetail {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 定义请求消息 GetGoodsHistoryReq，用于查询商品修改日志
+type GetGoodsHistoryReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`   // 商品 ID
+	Page          int32                  `protobuf:"varint,2,opt,name=Page,proto3" json:"Page,omitempty"`         // 页码，从 1 开始
+	PageSize      int32                  `protobuf:"varint,3,opt,name=PageSize,proto3" json:"PageSize,omitempty"` // 每页条数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGoodsHistoryReq) Reset() {
+	*x = GetGoodsHistoryReq{}
+	mi := &file_goods_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGoodsHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGoodsHistoryReq) ProtoMessage() {}
+
+func (x *GetGoodsHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGoodsHistoryReq.ProtoReflect.Descriptor instead.
+func (*GetGoodsHistoryReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetGoodsHistoryReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *GetGoodsHistoryReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetGoodsHistoryReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 定义响应消息 GoodsHistoryResp，用于返回商品修改日志
+type GoodsHistoryResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=Total,proto3" json:"Total,omitempty"` // 修改日志总数
+	Data          []*GoodsChange         `protobuf:"bytes,2,rep,name=Data,proto3" json:"Data,omitempty"`    // 当前页的修改日志
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoodsHistoryResp) Reset() {
+	*x = GoodsHistoryResp{}
+	mi := &file_goods_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoodsHistoryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoodsHistoryResp) ProtoMessage() {}
+
+func (x *GoodsHistoryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoodsHistoryResp.ProtoReflect.Descriptor instead.
+func (*GoodsHistoryResp) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GoodsHistoryResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GoodsHistoryResp) GetData() []*GoodsChange {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 定义商品修改日志的数据结构 GoodsChange
+type GoodsChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`            // 日志 ID
+	GoodsId       int64                  `protobuf:"varint,2,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`  // 商品 ID
+	Action        string                 `protobuf:"bytes,3,opt,name=Action,proto3" json:"Action,omitempty"`     // 操作类型：create / update / delete
+	OldValue      string                 `protobuf:"bytes,4,opt,name=OldValue,proto3" json:"OldValue,omitempty"` // 修改前的字段值（JSON）
+	NewValue      string                 `protobuf:"bytes,5,opt,name=NewValue,proto3" json:"NewValue,omitempty"` // 修改后的字段值（JSON）
+	Operator      string                 `protobuf:"bytes,6,opt,name=Operator,proto3" json:"Operator,omitempty"` // 操作人
+	Reason        string                 `protobuf:"bytes,7,opt,name=Reason,proto3" json:"Reason,omitempty"`     // 修改原因
+	CreateAt      string                 `protobuf:"bytes,8,opt,name=CreateAt,proto3" json:"CreateAt,omitempty"` // 修改时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoodsChange) Reset() {
+	*x = GoodsChange{}
+	mi := &file_goods_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoodsChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoodsChange) ProtoMessage() {}
+
+func (x *GoodsChange) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoodsChange.ProtoReflect.Descriptor instead.
+func (*GoodsChange) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GoodsChange) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GoodsChange) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *GoodsChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *GoodsChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *GoodsChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+func (x *GoodsChange) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *GoodsChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GoodsChange) GetCreateAt() string {
+	if x != nil {
+		return x.CreateAt
+	}
+	return ""
+}
+
+// 定义请求消息 SchedulePriceChangeReq，用于创建定时改价
+type SchedulePriceChangeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`             // 商品 ID
+	Price         int64                  `protobuf:"varint,2,opt,name=Price,proto3" json:"Price,omitempty"`                 // 生效期间的销售价格（分）
+	EffectiveFrom int64                  `protobuf:"varint,3,opt,name=EffectiveFrom,proto3" json:"EffectiveFrom,omitempty"` // 生效时间（Unix 秒），必须晚于当前时间
+	EffectiveTo   int64                  `protobuf:"varint,4,opt,name=EffectiveTo,proto3" json:"EffectiveTo,omitempty"`     // 结束时间（Unix 秒），0 表示生效后不再恢复原价
+	Reason        string                 `protobuf:"bytes,5,opt,name=Reason,proto3" json:"Reason,omitempty"`                // 改价原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeReq) Reset() {
+	*x = SchedulePriceChangeReq{}
+	mi := &file_goods_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeReq) ProtoMessage() {}
+
+func (x *SchedulePriceChangeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeReq.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SchedulePriceChangeReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *SchedulePriceChangeReq) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *SchedulePriceChangeReq) GetEffectiveFrom() int64 {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return 0
+}
+
+func (x *SchedulePriceChangeReq) GetEffectiveTo() int64 {
+	if x != nil {
+		return x.EffectiveTo
+	}
+	return 0
+}
+
+func (x *SchedulePriceChangeReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 定义请求消息 GetPriceHistoryReq，用于查询商品的价格时间线
+type GetPriceHistoryReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 商品 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryReq) Reset() {
+	*x = GetPriceHistoryReq{}
+	mi := &file_goods_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryReq) ProtoMessage() {}
+
+func (x *GetPriceHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryReq.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetPriceHistoryReq) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+// 定义响应消息 PriceHistoryResp，用于返回商品的价格时间线
+type PriceHistoryResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPrice  string                 `protobuf:"bytes,1,opt,name=CurrentPrice,proto3" json:"CurrentPrice,omitempty"` // 当前销售价格
+	Data          []*PriceChange         `protobuf:"bytes,2,rep,name=Data,proto3" json:"Data,omitempty"`                 // 价格变化，按生效时间排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceHistoryResp) Reset() {
+	*x = PriceHistoryResp{}
+	mi := &file_goods_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistoryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistoryResp) ProtoMessage() {}
+
+func (x *PriceHistoryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistoryResp.ProtoReflect.Descriptor instead.
+func (*PriceHistoryResp) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PriceHistoryResp) GetCurrentPrice() string {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return ""
+}
+
+func (x *PriceHistoryResp) GetData() []*PriceChange {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 定义价格变化的数据结构 PriceChange
+type PriceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    int64                  `protobuf:"varint,1,opt,name=ScheduleId,proto3" json:"ScheduleId,omitempty"`       // 定时改价 ID，手动改价为 0
+	Price         string                 `protobuf:"bytes,2,opt,name=Price,proto3" json:"Price,omitempty"`                  // 改价后的销售价格
+	OldPrice      string                 `protobuf:"bytes,3,opt,name=OldPrice,proto3" json:"OldPrice,omitempty"`            // 改价前的销售价格，定时改价未生效时为空
+	EffectiveFrom int64                  `protobuf:"varint,4,opt,name=EffectiveFrom,proto3" json:"EffectiveFrom,omitempty"` // 生效时间（Unix 秒）
+	EffectiveTo   int64                  `protobuf:"varint,5,opt,name=EffectiveTo,proto3" json:"EffectiveTo,omitempty"`     // 结束时间（Unix 秒），0 表示不恢复
+	Status        string                 `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`                // 状态：applied 手动改价已生效，pending 待生效，active 生效中，finished 已结束，canceled 已取消
+	Operator      string                 `protobuf:"bytes,7,opt,name=Operator,proto3" json:"Operator,omitempty"`            // 操作人
+	Reason        string                 `protobuf:"bytes,8,opt,name=Reason,proto3" json:"Reason,omitempty"`                // 改价原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_goods_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PriceChange) GetScheduleId() int64 {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return 0
+}
+
+func (x *PriceChange) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *PriceChange) GetOldPrice() string {
+	if x != nil {
+		return x.OldPrice
+	}
+	return ""
+}
+
+func (x *PriceChange) GetEffectiveFrom() int64 {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return 0
+}
+
+func (x *PriceChange) GetEffectiveTo() int64 {
+	if x != nil {
+		return x.EffectiveTo
+	}
+	return 0
+}
+
+func (x *PriceChange) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PriceChange) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *PriceChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 定义响应消息 GoodsDetail，用于返回商品详情
+type GoodsDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoodsId       int64                  `protobuf:"varint,1,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"`                      // 商品 ID
+	CategoryId    int64                  `protobuf:"varint,2,opt,name=CategoryId,proto3" json:"CategoryId,omitempty"`                // 分类 ID
+	Status        GoodsStatus            `protobuf:"varint,3,opt,name=Status,proto3,enum=proto.GoodsStatus" json:"Status,omitempty"` // 商品状态
+	Title         string                 `protobuf:"bytes,4,opt,name=Title,proto3" json:"Title,omitempty"`                           // 商品标题
+	Code          string                 `protobuf:"bytes,5,opt,name=Code,proto3" json:"Code,omitempty"`                             // 商品编码
+	BrandName     string                 `protobuf:"bytes,6,opt,name=BrandName,proto3" json:"BrandName,omitempty"`                   // 品牌名称
+	MarketPrice   string                 `protobuf:"bytes,7,opt,name=MarketPrice,proto3" json:"MarketPrice,omitempty"`               // 市场价格
+	Price         string                 `protobuf:"bytes,8,opt,name=Price,proto3" json:"Price,omitempty"`                           // 销售价格
+	Brief         string                 `protobuf:"bytes,9,opt,name=Brief,proto3" json:"Brief,omitempty"`                           // 商品简介
+	Stale         bool                   `protobuf:"varint,10,opt,name=Stale,proto3" json:"Stale,omitempty"`                         // 是否为降级返回的旧数据
+	Version       int32                  `protobuf:"varint,11,opt,name=Version,proto3" json:"Version,omitempty"`                     // 商品版本号，更新时作为期望版本号传入
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoodsDetail) Reset() {
+	*x = GoodsDetail{}
+	mi := &file_goods_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoodsDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoodsDetail) ProtoMessage() {}
+
+func (x *GoodsDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoodsDetail.ProtoReflect.Descriptor instead.
+func (*GoodsDetail) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GoodsDetail) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *GoodsDetail) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *GoodsDetail) GetStatus() GoodsStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GoodsStatus_GOODS_STATUS_ON_SHELF
+}
+
+func (x *GoodsDetail) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GoodsDetail) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GoodsDetail) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+func (x *GoodsDetail) GetMarketPrice() string {
+	if x != nil {
+		return x.MarketPrice
+	}
+	return ""
+}
+
+func (x *GoodsDetail) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *GoodsDetail) GetBrief() string {
+	if x != nil {
+		return x.Brief
+	}
+	return ""
+}
+
+func (x *GoodsDetail) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GoodsDetail) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// 定义请求消息 GetHotKeysReq，用于查询热点 key
+type GetHotKeysReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHotKeysReq) Reset() {
+	*x = GetHotKeysReq{}
+	mi := &file_goods_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHotKeysReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHotKeysReq) ProtoMessage() {}
+
+func (x *GetHotKeysReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHotKeysReq.ProtoReflect.Descriptor instead.
+func (*GetHotKeysReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{29}
+}
+
+// 定义响应消息 HotKeysResp，用于返回当前实例探测到的热点 key
+type HotKeysResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*HotKey              `protobuf:"bytes,1,rep,name=Data,proto3" json:"Data,omitempty"` // 热点 key 列表，按请求数从高到低排序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HotKeysResp) Reset() {
+	*x = HotKeysResp{}
+	mi := &file_goods_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HotKeysResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotKeysResp) ProtoMessage() {}
+
+func (x *HotKeysResp) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotKeysResp.ProtoReflect.Descriptor instead.
+func (*HotKeysResp) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *HotKeysResp) GetData() []*HotKey {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 定义热点 key 的数据结构 HotKey
+type HotKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`          // 缓存 key
+	GoodsId       int64                  `protobuf:"varint,2,opt,name=GoodsId,proto3" json:"GoodsId,omitempty"` // 商品 ID
+	Count         int64                  `protobuf:"varint,3,opt,name=Count,proto3" json:"Count,omitempty"`     // 滑动窗口内的请求数
+	Pinned        bool                   `protobuf:"varint,4,opt,name=Pinned,proto3" json:"Pinned,omitempty"`   // 是否已固定在本地缓存中
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HotKey) Reset() {
+	*x = HotKey{}
+	mi := &file_goods_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HotKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotKey) ProtoMessage() {}
+
+func (x *HotKey) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotKey.ProtoReflect.Descriptor instead.
+func (*HotKey) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *HotKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HotKey) GetGoodsId() int64 {
+	if x != nil {
+		return x.GoodsId
+	}
+	return 0
+}
+
+func (x *HotKey) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *HotKey) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+// 定义请求消息 WarmUpRoomReq，用于预热直播间商品缓存
+type WarmUpRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"` // 直播间 ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmUpRoomReq) Reset() {
+	*x = WarmUpRoomReq{}
+	mi := &file_goods_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmUpRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmUpRoomReq) ProtoMessage() {}
+
+func (x *WarmUpRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmUpRoomReq.ProtoReflect.Descriptor instead.
+func (*WarmUpRoomReq) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *WarmUpRoomReq) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+// 定义响应消息 WarmUpProgress，用于返回预热进度
+type WarmUpProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int64                  `protobuf:"varint,1,opt,name=RoomId,proto3" json:"RoomId,omitempty"`     // 直播间 ID
+	Total         int32                  `protobuf:"varint,2,opt,name=Total,proto3" json:"Total,omitempty"`       // 直播间绑定的商品总数
+	Loaded        int32                  `protobuf:"varint,3,opt,name=Loaded,proto3" json:"Loaded,omitempty"`     // 已加载到缓存的商品数
+	NotFound      int32                  `protobuf:"varint,4,opt,name=NotFound,proto3" json:"NotFound,omitempty"` // 不存在的商品数
+	Failed        int32                  `protobuf:"varint,5,opt,name=Failed,proto3" json:"Failed,omitempty"`     // 加载失败的商品数
+	Done          bool                   `protobuf:"varint,6,opt,name=Done,proto3" json:"Done,omitempty"`         // 是否已全部完成
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmUpProgress) Reset() {
+	*x = WarmUpProgress{}
+	mi := &file_goods_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmUpProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmUpProgress) ProtoMessage() {}
+
+func (x *WarmUpProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmUpProgress.ProtoReflect.Descriptor instead.
+func (*WarmUpProgress) Descriptor() ([]byte, []int) {
+	return file_goods_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WarmUpProgress) GetRoomId() int64 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *WarmUpProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *WarmUpProgress) GetLoaded() int32 {
+	if x != nil {
+		return x.Loaded
+	}
+	return 0
+}
+
+func (x *WarmUpProgress) GetNotFound() int32 {
+	if x != nil {
+		return x.NotFound
+	}
+	return 0
+}
+
+func (x *WarmUpProgress) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *WarmUpProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
 }
 
 var File_goods_proto protoreflect.FileDescriptor
 
 var file_goods_proto_rawDesc = string([]byte{
 	0x0a, 0x0b, 0x67, 0x6f, 0x6f, 0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x43, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73,
-	0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x5d, 0x0a, 0x0d, 0x47, 0x6f, 0x6f,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x71, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x49,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x6e, 0x73, 0x65, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55,
+	0x6e, 0x73, 0x65, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x73, 0x0a, 0x0d, 0x47, 0x6f, 0x6f,
 	0x64, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x75,
 	0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x03, 0x52, 0x0e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73,
 	0x49, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x6e,
-	0x66, 0x6f, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0xc1, 0x01, 0x0a, 0x09, 0x47, 0x6f, 0x6f,
-	0x64, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49,
+	0x66, 0x6f, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x6c,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x22, 0xd5,
+	0x01, 0x0a, 0x09, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07,
+	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47,
+	0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x43, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47,
+	0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d,
+	0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x22, 0x5e, 0x0a, 0x12, 0x42, 0x69, 0x6e, 0x64, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x54, 0x6f, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
+	0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x6f,
+	0x6f, 0x6d, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x4a, 0x0a, 0x16, 0x55, 0x6e, 0x62, 0x69, 0x6e, 0x64,
+	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71,
+	0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x49, 0x64, 0x22, 0x49, 0x0a, 0x13, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x6f, 0x6f,
+	0x6d, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f,
+	0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x03, 0x52, 0x08, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x73, 0x22, 0x46, 0x0a,
+	0x12, 0x53, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x47,
+	0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x6f,
+	0x6f, 0x6d, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f,
+	0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d,
+	0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xdb, 0x01, 0x0a, 0x0e, 0x52, 0x6f, 0x6f, 0x6d, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x12, 0x30, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x52, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x22, 0x45, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xd1, 0x01, 0x0a, 0x0e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x28, 0x0a, 0x05,
+	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52,
+	0x05, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x4d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61,
+	0x73, 0x6b, 0x12, 0x2d, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0f, 0x45,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01,
+	0x01, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x45, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4c, 0x0a,
+	0x16, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x08, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x5a, 0x0a, 0x14, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x04, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x11, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x22, 0xa1, 0x01, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x0f, 0x45, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0f, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x8c, 0x02, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x42,
+	0x72, 0x69, 0x65, 0x66, 0x22, 0x42, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64,
-	0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20,
-	0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x22, 0x45, 0x0a, 0x11,
-	0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65,
-	0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x55,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x22, 0x46, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f,
-	0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47,
-	0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f,
-	0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0xf5, 0x01, 0x0a, 0x0b,
-	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x47,
-	0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f,
-	0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
-	0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67,
-	0x6f, 0x72, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69,
-	0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x72, 0x61, 0x6e, 0x64,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x42, 0x72, 0x61, 0x6e,
-	0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50,
-	0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d, 0x61, 0x72, 0x6b,
-	0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x42, 0x72,
-	0x69, 0x65, 0x66, 0x32, 0xcc, 0x01, 0x0a, 0x05, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x40, 0x0a,
-	0x0e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x12,
+	0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x74, 0x0a, 0x14, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xd2,
+	0x01, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x1e, 0x0a, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x48, 0x00, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x18,
+	0x0a, 0x07, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x67, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x4d, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x04, 0x44, 0x61,
+	0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x04, 0x44, 0x61,
+	0x74, 0x61, 0x22, 0x5e, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x50, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x22, 0x50, 0x0a, 0x10, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x04,
+	0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x04,
+	0x44, 0x61, 0x74, 0x61, 0x22, 0xd7, 0x01, 0x0a, 0x0b, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x4f, 0x6c, 0x64, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4f, 0x6c, 0x64, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x22, 0xa8,
+	0x01, 0x0a, 0x16, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x45, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12,
+	0x20, 0x0a, 0x0b, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x54, 0x6f, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x54,
+	0x6f, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x2e, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12,
+	0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x22, 0x5e, 0x0a, 0x10, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x22, 0x0a,
+	0x0c, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x26, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0xf3, 0x01, 0x0a, 0x0b, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x4f, 0x6c, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x4f, 0x6c, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x45,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x46, 0x72, 0x6f,
+	0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x54, 0x6f,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x54, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0xb9, 0x02, 0x0a, 0x0b, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x43, 0x61, 0x74,
+	0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x43,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x43,
+	0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x42, 0x72, 0x69, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x53,
+	0x74, 0x61, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x53, 0x74, 0x61, 0x6c,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x0f, 0x0a, 0x0d, 0x47,
+	0x65, 0x74, 0x48, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x22, 0x30, 0x0a, 0x0b,
+	0x48, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x21, 0x0a, 0x04, 0x44,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x48, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0x62,
+	0x0a, 0x06, 0x48, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x69,
+	0x6e, 0x6e, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x50, 0x69, 0x6e, 0x6e,
+	0x65, 0x64, 0x22, 0x27, 0x0a, 0x0d, 0x57, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x52, 0x6f, 0x6f, 0x6d,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x0e,
+	0x57, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x52, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06,
+	0x4c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4c, 0x6f,
+	0x61, 0x64, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x2a, 0x95, 0x01, 0x0a,
+	0x0b, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x15,
+	0x47, 0x4f, 0x4f, 0x44, 0x53, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f, 0x4e, 0x5f,
+	0x53, 0x48, 0x45, 0x4c, 0x46, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x47, 0x4f, 0x4f, 0x44, 0x53,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f, 0x46, 0x46, 0x5f, 0x53, 0x48, 0x45, 0x4c,
+	0x46, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x47, 0x4f, 0x4f, 0x44, 0x53, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x44, 0x52, 0x41, 0x46, 0x54, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x47,
+	0x4f, 0x4f, 0x44, 0x53, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x44, 0x45,
+	0x52, 0x5f, 0x52, 0x45, 0x56, 0x49, 0x45, 0x57, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x47, 0x4f,
+	0x4f, 0x44, 0x53, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54,
+	0x45, 0x44, 0x10, 0x04, 0x2a, 0xba, 0x01, 0x0a, 0x12, 0x52, 0x6f, 0x6f, 0x6d, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12,
+	0x0a, 0x0e, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54,
+	0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x43, 0x55, 0x52, 0x52,
+	0x45, 0x4e, 0x54, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x02, 0x12, 0x15, 0x0a,
+	0x11, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x47, 0x4f, 0x4f, 0x44, 0x53, 0x5f, 0x41, 0x44, 0x44,
+	0x45, 0x44, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x47, 0x4f,
+	0x4f, 0x44, 0x53, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x04, 0x12, 0x19, 0x0a,
+	0x15, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x47, 0x4f, 0x4f, 0x44, 0x53, 0x5f, 0x52, 0x45, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x45, 0x44, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x50, 0x52, 0x49, 0x43, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10,
+	0x06, 0x32, 0xe0, 0x09, 0x0a, 0x05, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x40, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x18, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x42, 0x79,
+	0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x6f, 0x6f, 0x64, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3e, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12,
 	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73,
-	0x42, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12,
-	0x3e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69,
-	0x6c, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f,
-	0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12,
-	0x41, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65,
-	0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x41, 0x0a,
+	0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x1a,
+	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x38, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12,
+	0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47,
+	0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x51, 0x0a, 0x13, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47,
+	0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71,
+	0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x12, 0x38, 0x0a,
+	0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x15, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x35, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44,
+	0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x12, 0x36, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x45, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x48, 0x0a, 0x13, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x45, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x3d, 0x0a, 0x0f, 0x42, 0x69, 0x6e, 0x64, 0x47, 0x6f, 0x6f, 0x64,
+	0x73, 0x54, 0x6f, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x42, 0x69, 0x6e, 0x64, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x54, 0x6f, 0x52, 0x6f, 0x6f, 0x6d, 0x52,
+	0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x13, 0x55, 0x6e, 0x62, 0x69, 0x6e, 0x64, 0x47, 0x6f, 0x6f,
+	0x64, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x55, 0x6e, 0x62, 0x69, 0x6e, 0x64, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x46, 0x72,
+	0x6f, 0x6d, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x10, 0x52, 0x65,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x6f, 0x6f, 0x6d, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x1a,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x6f,
+	0x6f, 0x6d, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0f, 0x53,
+	0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x19,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x6f, 0x6f, 0x6d, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x6f, 0x6f, 0x6d, 0x47, 0x6f,
+	0x6f, 0x64, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
+	0x6f, 0x6f, 0x6d, 0x47, 0x6f, 0x6f, 0x64, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12,
+	0x36, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x14, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6f, 0x74, 0x4b,
+	0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3b, 0x0a, 0x0a, 0x57, 0x61, 0x72, 0x6d, 0x55,
+	0x70, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61,
+	0x72, 0x6d, 0x55, 0x70, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x30, 0x01, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -572,29 +2780,107 @@ func file_goods_proto_rawDescGZIP() []byte {
 	return file_goods_proto_rawDescData
 }
 
-var file_goods_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_goods_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_goods_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_goods_proto_goTypes = []any{
-	(*Response)(nil),             // 0: proto.Response
-	(*GetGoodsByRoomReq)(nil),    // 1: proto.GetGoodsByRoomReq
-	(*GoodsListResp)(nil),        // 2: proto.GoodsListResp
-	(*GoodsInfo)(nil),            // 3: proto.GoodsInfo
-	(*GetGoodsDetailReq)(nil),    // 4: proto.GetGoodsDetailReq
-	(*UpdateGoodsDetailReq)(nil), // 5: proto.UpdateGoodsDetailReq
-	(*GoodsDetail)(nil),          // 6: proto.GoodsDetail
+	(GoodsStatus)(0),               // 0: proto.GoodsStatus
+	(RoomGoodsEventType)(0),        // 1: proto.RoomGoodsEventType
+	(*Response)(nil),               // 2: proto.Response
+	(*GetGoodsByRoomReq)(nil),      // 3: proto.GetGoodsByRoomReq
+	(*GoodsListResp)(nil),          // 4: proto.GoodsListResp
+	(*GoodsInfo)(nil),              // 5: proto.GoodsInfo
+	(*BindGoodsToRoomReq)(nil),     // 6: proto.BindGoodsToRoomReq
+	(*UnbindGoodsFromRoomReq)(nil), // 7: proto.UnbindGoodsFromRoomReq
+	(*ReorderRoomGoodsReq)(nil),    // 8: proto.ReorderRoomGoodsReq
+	(*SetCurrentGoodsReq)(nil),     // 9: proto.SetCurrentGoodsReq
+	(*WatchRoomGoodsReq)(nil),      // 10: proto.WatchRoomGoodsReq
+	(*RoomGoodsEvent)(nil),         // 11: proto.RoomGoodsEvent
+	(*GetGoodsDetailReq)(nil),      // 12: proto.GetGoodsDetailReq
+	(*UpdateGoodsReq)(nil),         // 13: proto.UpdateGoodsReq
+	(*BatchGetGoodsDetailReq)(nil), // 14: proto.BatchGetGoodsDetailReq
+	(*BatchGoodsDetailResp)(nil),   // 15: proto.BatchGoodsDetailResp
+	(*GoodsDetailResult)(nil),      // 16: proto.GoodsDetailResult
+	(*UpdateGoodsDetailReq)(nil),   // 17: proto.UpdateGoodsDetailReq
+	(*CreateGoodsReq)(nil),         // 18: proto.CreateGoodsReq
+	(*DeleteGoodsReq)(nil),         // 19: proto.DeleteGoodsReq
+	(*ChangeGoodsStatusReq)(nil),   // 20: proto.ChangeGoodsStatusReq
+	(*ListGoodsReq)(nil),           // 21: proto.ListGoodsReq
+	(*ListGoodsResp)(nil),          // 22: proto.ListGoodsResp
+	(*GetGoodsHistoryReq)(nil),     // 23: proto.GetGoodsHistoryReq
+	(*GoodsHistoryResp)(nil),       // 24: proto.GoodsHistoryResp
+	(*GoodsChange)(nil),            // 25: proto.GoodsChange
+	(*SchedulePriceChangeReq)(nil), // 26: proto.SchedulePriceChangeReq
+	(*GetPriceHistoryReq)(nil),     // 27: proto.GetPriceHistoryReq
+	(*PriceHistoryResp)(nil),       // 28: proto.PriceHistoryResp
+	(*PriceChange)(nil),            // 29: proto.PriceChange
+	(*GoodsDetail)(nil),            // 30: proto.GoodsDetail
+	(*GetHotKeysReq)(nil),          // 31: proto.GetHotKeysReq
+	(*HotKeysResp)(nil),            // 32: proto.HotKeysResp
+	(*HotKey)(nil),                 // 33: proto.HotKey
+	(*WarmUpRoomReq)(nil),          // 34: proto.WarmUpRoomReq
+	(*WarmUpProgress)(nil),         // 35: proto.WarmUpProgress
+	(*fieldmaskpb.FieldMask)(nil),  // 36: google.protobuf.FieldMask
 }
 var file_goods_proto_depIdxs = []int32{
-	3, // 0: proto.GoodsListResp.Data:type_name -> proto.GoodsInfo
-	1, // 1: proto.Goods.GetGoodsByRoom:input_type -> proto.GetGoodsByRoomReq
-	4, // 2: proto.Goods.GetGoodsDetail:input_type -> proto.GetGoodsDetailReq
-	5, // 3: proto.Goods.UpdateGoodsDetail:input_type -> proto.UpdateGoodsDetailReq
-	2, // 4: proto.Goods.GetGoodsByRoom:output_type -> proto.GoodsListResp
-	6, // 5: proto.Goods.GetGoodsDetail:output_type -> proto.GoodsDetail
-	0, // 6: proto.Goods.UpdateGoodsDetail:output_type -> proto.Response
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5,  // 0: proto.GoodsListResp.Data:type_name -> proto.GoodsInfo
+	0,  // 1: proto.GoodsInfo.Status:type_name -> proto.GoodsStatus
+	1,  // 2: proto.RoomGoodsEvent.Type:type_name -> proto.RoomGoodsEventType
+	4,  // 3: proto.RoomGoodsEvent.Snapshot:type_name -> proto.GoodsListResp
+	30, // 4: proto.UpdateGoodsReq.Goods:type_name -> proto.GoodsDetail
+	36, // 5: proto.UpdateGoodsReq.UpdateMask:type_name -> google.protobuf.FieldMask
+	16, // 6: proto.BatchGoodsDetailResp.Data:type_name -> proto.GoodsDetailResult
+	30, // 7: proto.GoodsDetailResult.Detail:type_name -> proto.GoodsDetail
+	0,  // 8: proto.CreateGoodsReq.Status:type_name -> proto.GoodsStatus
+	0,  // 9: proto.ChangeGoodsStatusReq.Status:type_name -> proto.GoodsStatus
+	0,  // 10: proto.ListGoodsReq.Status:type_name -> proto.GoodsStatus
+	30, // 11: proto.ListGoodsResp.Data:type_name -> proto.GoodsDetail
+	25, // 12: proto.GoodsHistoryResp.Data:type_name -> proto.GoodsChange
+	29, // 13: proto.PriceHistoryResp.Data:type_name -> proto.PriceChange
+	0,  // 14: proto.GoodsDetail.Status:type_name -> proto.GoodsStatus
+	33, // 15: proto.HotKeysResp.Data:type_name -> proto.HotKey
+	3,  // 16: proto.Goods.GetGoodsByRoom:input_type -> proto.GetGoodsByRoomReq
+	12, // 17: proto.Goods.GetGoodsDetail:input_type -> proto.GetGoodsDetailReq
+	17, // 18: proto.Goods.UpdateGoodsDetail:input_type -> proto.UpdateGoodsDetailReq
+	13, // 19: proto.Goods.UpdateGoods:input_type -> proto.UpdateGoodsReq
+	14, // 20: proto.Goods.BatchGetGoodsDetail:input_type -> proto.BatchGetGoodsDetailReq
+	18, // 21: proto.Goods.CreateGoods:input_type -> proto.CreateGoodsReq
+	19, // 22: proto.Goods.DeleteGoods:input_type -> proto.DeleteGoodsReq
+	20, // 23: proto.Goods.ChangeGoodsStatus:input_type -> proto.ChangeGoodsStatusReq
+	21, // 24: proto.Goods.ListGoods:input_type -> proto.ListGoodsReq
+	23, // 25: proto.Goods.GetGoodsHistory:input_type -> proto.GetGoodsHistoryReq
+	26, // 26: proto.Goods.SchedulePriceChange:input_type -> proto.SchedulePriceChangeReq
+	27, // 27: proto.Goods.GetPriceHistory:input_type -> proto.GetPriceHistoryReq
+	6,  // 28: proto.Goods.BindGoodsToRoom:input_type -> proto.BindGoodsToRoomReq
+	7,  // 29: proto.Goods.UnbindGoodsFromRoom:input_type -> proto.UnbindGoodsFromRoomReq
+	8,  // 30: proto.Goods.ReorderRoomGoods:input_type -> proto.ReorderRoomGoodsReq
+	9,  // 31: proto.Goods.SetCurrentGoods:input_type -> proto.SetCurrentGoodsReq
+	10, // 32: proto.Goods.WatchRoomGoods:input_type -> proto.WatchRoomGoodsReq
+	31, // 33: proto.Goods.GetHotKeys:input_type -> proto.GetHotKeysReq
+	34, // 34: proto.Goods.WarmUpRoom:input_type -> proto.WarmUpRoomReq
+	4,  // 35: proto.Goods.GetGoodsByRoom:output_type -> proto.GoodsListResp
+	30, // 36: proto.Goods.GetGoodsDetail:output_type -> proto.GoodsDetail
+	2,  // 37: proto.Goods.UpdateGoodsDetail:output_type -> proto.Response
+	30, // 38: proto.Goods.UpdateGoods:output_type -> proto.GoodsDetail
+	15, // 39: proto.Goods.BatchGetGoodsDetail:output_type -> proto.BatchGoodsDetailResp
+	30, // 40: proto.Goods.CreateGoods:output_type -> proto.GoodsDetail
+	2,  // 41: proto.Goods.DeleteGoods:output_type -> proto.Response
+	30, // 42: proto.Goods.ChangeGoodsStatus:output_type -> proto.GoodsDetail
+	22, // 43: proto.Goods.ListGoods:output_type -> proto.ListGoodsResp
+	24, // 44: proto.Goods.GetGoodsHistory:output_type -> proto.GoodsHistoryResp
+	29, // 45: proto.Goods.SchedulePriceChange:output_type -> proto.PriceChange
+	28, // 46: proto.Goods.GetPriceHistory:output_type -> proto.PriceHistoryResp
+	2,  // 47: proto.Goods.BindGoodsToRoom:output_type -> proto.Response
+	2,  // 48: proto.Goods.UnbindGoodsFromRoom:output_type -> proto.Response
+	2,  // 49: proto.Goods.ReorderRoomGoods:output_type -> proto.Response
+	2,  // 50: proto.Goods.SetCurrentGoods:output_type -> proto.Response
+	11, // 51: proto.Goods.WatchRoomGoods:output_type -> proto.RoomGoodsEvent
+	32, // 52: proto.Goods.GetHotKeys:output_type -> proto.HotKeysResp
+	35, // 53: proto.Goods.WarmUpRoom:output_type -> proto.WarmUpProgress
+	35, // [35:54] is the sub-list for method output_type
+	16, // [16:35] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_goods_proto_init() }
@@ -602,18 +2888,22 @@ func file_goods_proto_init() {
 	if File_goods_proto != nil {
 		return
 	}
+	file_goods_proto_msgTypes[11].OneofWrappers = []any{}
+	file_goods_proto_msgTypes[15].OneofWrappers = []any{}
+	file_goods_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_goods_proto_rawDesc), len(file_goods_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_goods_proto_goTypes,
 		DependencyIndexes: file_goods_proto_depIdxs,
+		EnumInfos:         file_goods_proto_enumTypes,
 		MessageInfos:      file_goods_proto_msgTypes,
 	}.Build()
 	File_goods_proto = out.File
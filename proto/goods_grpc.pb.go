@@ -19,9 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Goods_GetGoodsByRoom_FullMethodName    = "/proto.Goods/GetGoodsByRoom"
-	Goods_GetGoodsDetail_FullMethodName    = "/proto.Goods/GetGoodsDetail"
-	Goods_UpdateGoodsDetail_FullMethodName = "/proto.Goods/UpdateGoodsDetail"
+	Goods_GetGoodsByRoom_FullMethodName      = "/proto.Goods/GetGoodsByRoom"
+	Goods_GetGoodsDetail_FullMethodName      = "/proto.Goods/GetGoodsDetail"
+	Goods_UpdateGoodsDetail_FullMethodName   = "/proto.Goods/UpdateGoodsDetail"
+	Goods_UpdateGoods_FullMethodName         = "/proto.Goods/UpdateGoods"
+	Goods_BatchGetGoodsDetail_FullMethodName = "/proto.Goods/BatchGetGoodsDetail"
+	Goods_CreateGoods_FullMethodName         = "/proto.Goods/CreateGoods"
+	Goods_DeleteGoods_FullMethodName         = "/proto.Goods/DeleteGoods"
+	Goods_ChangeGoodsStatus_FullMethodName   = "/proto.Goods/ChangeGoodsStatus"
+	Goods_ListGoods_FullMethodName           = "/proto.Goods/ListGoods"
+	Goods_GetGoodsHistory_FullMethodName     = "/proto.Goods/GetGoodsHistory"
+	Goods_SchedulePriceChange_FullMethodName = "/proto.Goods/SchedulePriceChange"
+	Goods_GetPriceHistory_FullMethodName     = "/proto.Goods/GetPriceHistory"
+	Goods_BindGoodsToRoom_FullMethodName     = "/proto.Goods/BindGoodsToRoom"
+	Goods_UnbindGoodsFromRoom_FullMethodName = "/proto.Goods/UnbindGoodsFromRoom"
+	Goods_ReorderRoomGoods_FullMethodName    = "/proto.Goods/ReorderRoomGoods"
+	Goods_SetCurrentGoods_FullMethodName     = "/proto.Goods/SetCurrentGoods"
+	Goods_WatchRoomGoods_FullMethodName      = "/proto.Goods/WatchRoomGoods"
+	Goods_GetHotKeys_FullMethodName          = "/proto.Goods/GetHotKeys"
+	Goods_WarmUpRoom_FullMethodName          = "/proto.Goods/WarmUpRoom"
 )
 
 // GoodsClient is the client API for Goods service.
@@ -35,6 +51,38 @@ type GoodsClient interface {
 	// 定义一个 RPC 方法 GetGoodsDetail，用于获取商品详情页
 	GetGoodsDetail(ctx context.Context, in *GetGoodsDetailReq, opts ...grpc.CallOption) (*GoodsDetail, error)
 	UpdateGoodsDetail(ctx context.Context, in *UpdateGoodsDetailReq, opts ...grpc.CallOption) (*Response, error)
+	// 按字段掩码部分更新商品信息，只更新 UpdateMask 中指定的字段
+	UpdateGoods(ctx context.Context, in *UpdateGoodsReq, opts ...grpc.CallOption) (*GoodsDetail, error)
+	// 批量获取商品详情，结果按请求中的商品顺序返回
+	BatchGetGoodsDetail(ctx context.Context, in *BatchGetGoodsDetailReq, opts ...grpc.CallOption) (*BatchGoodsDetailResp, error)
+	// 新增商品，商品 ID 和商品编码不能重复
+	CreateGoods(ctx context.Context, in *CreateGoodsReq, opts ...grpc.CallOption) (*GoodsDetail, error)
+	// 删除商品（软删除）
+	DeleteGoods(ctx context.Context, in *DeleteGoodsReq, opts ...grpc.CallOption) (*Response, error)
+	// 修改商品状态，只允许按规定的流转变更，例如草稿提交审核、审核通过上架、上架后下架
+	ChangeGoodsStatus(ctx context.Context, in *ChangeGoodsStatusReq, opts ...grpc.CallOption) (*GoodsDetail, error)
+	// 按条件分页查询商品列表
+	ListGoods(ctx context.Context, in *ListGoodsReq, opts ...grpc.CallOption) (*ListGoodsResp, error)
+	// 分页查询商品的修改日志，按修改时间倒序返回
+	GetGoodsHistory(ctx context.Context, in *GetGoodsHistoryReq, opts ...grpc.CallOption) (*GoodsHistoryResp, error)
+	// 创建定时改价，到达生效时间后自动改价，到达结束时间后自动恢复原价
+	SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeReq, opts ...grpc.CallOption) (*PriceChange, error)
+	// 查询商品的价格时间线，包括手动改价和定时改价
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryReq, opts ...grpc.CallOption) (*PriceHistoryResp, error)
+	// 将商品绑定到直播间
+	BindGoodsToRoom(ctx context.Context, in *BindGoodsToRoomReq, opts ...grpc.CallOption) (*Response, error)
+	// 解绑直播间的商品
+	UnbindGoodsFromRoom(ctx context.Context, in *UnbindGoodsFromRoomReq, opts ...grpc.CallOption) (*Response, error)
+	// 按给定顺序重新排列直播间的商品
+	ReorderRoomGoods(ctx context.Context, in *ReorderRoomGoodsReq, opts ...grpc.CallOption) (*Response, error)
+	// 切换直播间当前讲解的商品
+	SetCurrentGoods(ctx context.Context, in *SetCurrentGoodsReq, opts ...grpc.CallOption) (*Response, error)
+	// 订阅直播间商品变化，先推送商品列表快照，之后推送增量事件
+	WatchRoomGoods(ctx context.Context, in *WatchRoomGoodsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomGoodsEvent], error)
+	// 管理接口：查询当前实例探测到的热点商品
+	GetHotKeys(ctx context.Context, in *GetHotKeysReq, opts ...grpc.CallOption) (*HotKeysResp, error)
+	// 管理接口：预热直播间商品缓存，开播前调用，流式返回预热进度
+	WarmUpRoom(ctx context.Context, in *WarmUpRoomReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WarmUpProgress], error)
 }
 
 type goodsClient struct {
@@ -75,6 +123,184 @@ func (c *goodsClient) UpdateGoodsDetail(ctx context.Context, in *UpdateGoodsDeta
 	return out, nil
 }
 
+func (c *goodsClient) UpdateGoods(ctx context.Context, in *UpdateGoodsReq, opts ...grpc.CallOption) (*GoodsDetail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoodsDetail)
+	err := c.cc.Invoke(ctx, Goods_UpdateGoods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) BatchGetGoodsDetail(ctx context.Context, in *BatchGetGoodsDetailReq, opts ...grpc.CallOption) (*BatchGoodsDetailResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGoodsDetailResp)
+	err := c.cc.Invoke(ctx, Goods_BatchGetGoodsDetail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) CreateGoods(ctx context.Context, in *CreateGoodsReq, opts ...grpc.CallOption) (*GoodsDetail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoodsDetail)
+	err := c.cc.Invoke(ctx, Goods_CreateGoods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) DeleteGoods(ctx context.Context, in *DeleteGoodsReq, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Goods_DeleteGoods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) ChangeGoodsStatus(ctx context.Context, in *ChangeGoodsStatusReq, opts ...grpc.CallOption) (*GoodsDetail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoodsDetail)
+	err := c.cc.Invoke(ctx, Goods_ChangeGoodsStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) ListGoods(ctx context.Context, in *ListGoodsReq, opts ...grpc.CallOption) (*ListGoodsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGoodsResp)
+	err := c.cc.Invoke(ctx, Goods_ListGoods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) GetGoodsHistory(ctx context.Context, in *GetGoodsHistoryReq, opts ...grpc.CallOption) (*GoodsHistoryResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoodsHistoryResp)
+	err := c.cc.Invoke(ctx, Goods_GetGoodsHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) SchedulePriceChange(ctx context.Context, in *SchedulePriceChangeReq, opts ...grpc.CallOption) (*PriceChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceChange)
+	err := c.cc.Invoke(ctx, Goods_SchedulePriceChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryReq, opts ...grpc.CallOption) (*PriceHistoryResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceHistoryResp)
+	err := c.cc.Invoke(ctx, Goods_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) BindGoodsToRoom(ctx context.Context, in *BindGoodsToRoomReq, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Goods_BindGoodsToRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) UnbindGoodsFromRoom(ctx context.Context, in *UnbindGoodsFromRoomReq, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Goods_UnbindGoodsFromRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) ReorderRoomGoods(ctx context.Context, in *ReorderRoomGoodsReq, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Goods_ReorderRoomGoods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) SetCurrentGoods(ctx context.Context, in *SetCurrentGoodsReq, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, Goods_SetCurrentGoods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) WatchRoomGoods(ctx context.Context, in *WatchRoomGoodsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomGoodsEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Goods_ServiceDesc.Streams[0], Goods_WatchRoomGoods_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRoomGoodsReq, RoomGoodsEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Goods_WatchRoomGoodsClient = grpc.ServerStreamingClient[RoomGoodsEvent]
+
+func (c *goodsClient) GetHotKeys(ctx context.Context, in *GetHotKeysReq, opts ...grpc.CallOption) (*HotKeysResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HotKeysResp)
+	err := c.cc.Invoke(ctx, Goods_GetHotKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsClient) WarmUpRoom(ctx context.Context, in *WarmUpRoomReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WarmUpProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Goods_ServiceDesc.Streams[1], Goods_WarmUpRoom_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WarmUpRoomReq, WarmUpProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Goods_WarmUpRoomClient = grpc.ServerStreamingClient[WarmUpProgress]
+
 // GoodsServer is the server API for Goods service.
 // All implementations must embed UnimplementedGoodsServer
 // for forward compatibility.
@@ -86,6 +312,38 @@ type GoodsServer interface {
 	// 定义一个 RPC 方法 GetGoodsDetail，用于获取商品详情页
 	GetGoodsDetail(context.Context, *GetGoodsDetailReq) (*GoodsDetail, error)
 	UpdateGoodsDetail(context.Context, *UpdateGoodsDetailReq) (*Response, error)
+	// 按字段掩码部分更新商品信息，只更新 UpdateMask 中指定的字段
+	UpdateGoods(context.Context, *UpdateGoodsReq) (*GoodsDetail, error)
+	// 批量获取商品详情，结果按请求中的商品顺序返回
+	BatchGetGoodsDetail(context.Context, *BatchGetGoodsDetailReq) (*BatchGoodsDetailResp, error)
+	// 新增商品，商品 ID 和商品编码不能重复
+	CreateGoods(context.Context, *CreateGoodsReq) (*GoodsDetail, error)
+	// 删除商品（软删除）
+	DeleteGoods(context.Context, *DeleteGoodsReq) (*Response, error)
+	// 修改商品状态，只允许按规定的流转变更，例如草稿提交审核、审核通过上架、上架后下架
+	ChangeGoodsStatus(context.Context, *ChangeGoodsStatusReq) (*GoodsDetail, error)
+	// 按条件分页查询商品列表
+	ListGoods(context.Context, *ListGoodsReq) (*ListGoodsResp, error)
+	// 分页查询商品的修改日志，按修改时间倒序返回
+	GetGoodsHistory(context.Context, *GetGoodsHistoryReq) (*GoodsHistoryResp, error)
+	// 创建定时改价，到达生效时间后自动改价，到达结束时间后自动恢复原价
+	SchedulePriceChange(context.Context, *SchedulePriceChangeReq) (*PriceChange, error)
+	// 查询商品的价格时间线，包括手动改价和定时改价
+	GetPriceHistory(context.Context, *GetPriceHistoryReq) (*PriceHistoryResp, error)
+	// 将商品绑定到直播间
+	BindGoodsToRoom(context.Context, *BindGoodsToRoomReq) (*Response, error)
+	// 解绑直播间的商品
+	UnbindGoodsFromRoom(context.Context, *UnbindGoodsFromRoomReq) (*Response, error)
+	// 按给定顺序重新排列直播间的商品
+	ReorderRoomGoods(context.Context, *ReorderRoomGoodsReq) (*Response, error)
+	// 切换直播间当前讲解的商品
+	SetCurrentGoods(context.Context, *SetCurrentGoodsReq) (*Response, error)
+	// 订阅直播间商品变化，先推送商品列表快照，之后推送增量事件
+	WatchRoomGoods(*WatchRoomGoodsReq, grpc.ServerStreamingServer[RoomGoodsEvent]) error
+	// 管理接口：查询当前实例探测到的热点商品
+	GetHotKeys(context.Context, *GetHotKeysReq) (*HotKeysResp, error)
+	// 管理接口：预热直播间商品缓存，开播前调用，流式返回预热进度
+	WarmUpRoom(*WarmUpRoomReq, grpc.ServerStreamingServer[WarmUpProgress]) error
 	mustEmbedUnimplementedGoodsServer()
 }
 
@@ -105,6 +363,54 @@ func (UnimplementedGoodsServer) GetGoodsDetail(context.Context, *GetGoodsDetailR
 func (UnimplementedGoodsServer) UpdateGoodsDetail(context.Context, *UpdateGoodsDetailReq) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateGoodsDetail not implemented")
 }
+func (UnimplementedGoodsServer) UpdateGoods(context.Context, *UpdateGoodsReq) (*GoodsDetail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGoods not implemented")
+}
+func (UnimplementedGoodsServer) BatchGetGoodsDetail(context.Context, *BatchGetGoodsDetailReq) (*BatchGoodsDetailResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetGoodsDetail not implemented")
+}
+func (UnimplementedGoodsServer) CreateGoods(context.Context, *CreateGoodsReq) (*GoodsDetail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGoods not implemented")
+}
+func (UnimplementedGoodsServer) DeleteGoods(context.Context, *DeleteGoodsReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGoods not implemented")
+}
+func (UnimplementedGoodsServer) ChangeGoodsStatus(context.Context, *ChangeGoodsStatusReq) (*GoodsDetail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeGoodsStatus not implemented")
+}
+func (UnimplementedGoodsServer) ListGoods(context.Context, *ListGoodsReq) (*ListGoodsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGoods not implemented")
+}
+func (UnimplementedGoodsServer) GetGoodsHistory(context.Context, *GetGoodsHistoryReq) (*GoodsHistoryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGoodsHistory not implemented")
+}
+func (UnimplementedGoodsServer) SchedulePriceChange(context.Context, *SchedulePriceChangeReq) (*PriceChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePriceChange not implemented")
+}
+func (UnimplementedGoodsServer) GetPriceHistory(context.Context, *GetPriceHistoryReq) (*PriceHistoryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedGoodsServer) BindGoodsToRoom(context.Context, *BindGoodsToRoomReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BindGoodsToRoom not implemented")
+}
+func (UnimplementedGoodsServer) UnbindGoodsFromRoom(context.Context, *UnbindGoodsFromRoomReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbindGoodsFromRoom not implemented")
+}
+func (UnimplementedGoodsServer) ReorderRoomGoods(context.Context, *ReorderRoomGoodsReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderRoomGoods not implemented")
+}
+func (UnimplementedGoodsServer) SetCurrentGoods(context.Context, *SetCurrentGoodsReq) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCurrentGoods not implemented")
+}
+func (UnimplementedGoodsServer) WatchRoomGoods(*WatchRoomGoodsReq, grpc.ServerStreamingServer[RoomGoodsEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRoomGoods not implemented")
+}
+func (UnimplementedGoodsServer) GetHotKeys(context.Context, *GetHotKeysReq) (*HotKeysResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHotKeys not implemented")
+}
+func (UnimplementedGoodsServer) WarmUpRoom(*WarmUpRoomReq, grpc.ServerStreamingServer[WarmUpProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method WarmUpRoom not implemented")
+}
 func (UnimplementedGoodsServer) mustEmbedUnimplementedGoodsServer() {}
 func (UnimplementedGoodsServer) testEmbeddedByValue()               {}
 
@@ -180,6 +486,280 @@ func _Goods_UpdateGoodsDetail_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Goods_UpdateGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGoodsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).UpdateGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_UpdateGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).UpdateGoods(ctx, req.(*UpdateGoodsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_BatchGetGoodsDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetGoodsDetailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).BatchGetGoodsDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_BatchGetGoodsDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).BatchGetGoodsDetail(ctx, req.(*BatchGetGoodsDetailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_CreateGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGoodsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).CreateGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_CreateGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).CreateGoods(ctx, req.(*CreateGoodsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_DeleteGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGoodsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).DeleteGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_DeleteGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).DeleteGoods(ctx, req.(*DeleteGoodsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_ChangeGoodsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeGoodsStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).ChangeGoodsStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_ChangeGoodsStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).ChangeGoodsStatus(ctx, req.(*ChangeGoodsStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_ListGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGoodsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).ListGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_ListGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).ListGoods(ctx, req.(*ListGoodsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_GetGoodsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGoodsHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).GetGoodsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_GetGoodsHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).GetGoodsHistory(ctx, req.(*GetGoodsHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_SchedulePriceChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePriceChangeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).SchedulePriceChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_SchedulePriceChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).SchedulePriceChange(ctx, req.(*SchedulePriceChangeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).GetPriceHistory(ctx, req.(*GetPriceHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_BindGoodsToRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BindGoodsToRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).BindGoodsToRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_BindGoodsToRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).BindGoodsToRoom(ctx, req.(*BindGoodsToRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_UnbindGoodsFromRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbindGoodsFromRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).UnbindGoodsFromRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_UnbindGoodsFromRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).UnbindGoodsFromRoom(ctx, req.(*UnbindGoodsFromRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_ReorderRoomGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderRoomGoodsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).ReorderRoomGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_ReorderRoomGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).ReorderRoomGoods(ctx, req.(*ReorderRoomGoodsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_SetCurrentGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCurrentGoodsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).SetCurrentGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_SetCurrentGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).SetCurrentGoods(ctx, req.(*SetCurrentGoodsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_WatchRoomGoods_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRoomGoodsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoodsServer).WatchRoomGoods(m, &grpc.GenericServerStream[WatchRoomGoodsReq, RoomGoodsEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Goods_WatchRoomGoodsServer = grpc.ServerStreamingServer[RoomGoodsEvent]
+
+func _Goods_GetHotKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHotKeysReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServer).GetHotKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Goods_GetHotKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServer).GetHotKeys(ctx, req.(*GetHotKeysReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goods_WarmUpRoom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WarmUpRoomReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoodsServer).WarmUpRoom(m, &grpc.GenericServerStream[WarmUpRoomReq, WarmUpProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Goods_WarmUpRoomServer = grpc.ServerStreamingServer[WarmUpProgress]
+
 // Goods_ServiceDesc is the grpc.ServiceDesc for Goods service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,7 +779,74 @@ var Goods_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateGoodsDetail",
 			Handler:    _Goods_UpdateGoodsDetail_Handler,
 		},
+		{
+			MethodName: "UpdateGoods",
+			Handler:    _Goods_UpdateGoods_Handler,
+		},
+		{
+			MethodName: "BatchGetGoodsDetail",
+			Handler:    _Goods_BatchGetGoodsDetail_Handler,
+		},
+		{
+			MethodName: "CreateGoods",
+			Handler:    _Goods_CreateGoods_Handler,
+		},
+		{
+			MethodName: "DeleteGoods",
+			Handler:    _Goods_DeleteGoods_Handler,
+		},
+		{
+			MethodName: "ChangeGoodsStatus",
+			Handler:    _Goods_ChangeGoodsStatus_Handler,
+		},
+		{
+			MethodName: "ListGoods",
+			Handler:    _Goods_ListGoods_Handler,
+		},
+		{
+			MethodName: "GetGoodsHistory",
+			Handler:    _Goods_GetGoodsHistory_Handler,
+		},
+		{
+			MethodName: "SchedulePriceChange",
+			Handler:    _Goods_SchedulePriceChange_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _Goods_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "BindGoodsToRoom",
+			Handler:    _Goods_BindGoodsToRoom_Handler,
+		},
+		{
+			MethodName: "UnbindGoodsFromRoom",
+			Handler:    _Goods_UnbindGoodsFromRoom_Handler,
+		},
+		{
+			MethodName: "ReorderRoomGoods",
+			Handler:    _Goods_ReorderRoomGoods_Handler,
+		},
+		{
+			MethodName: "SetCurrentGoods",
+			Handler:    _Goods_SetCurrentGoods_Handler,
+		},
+		{
+			MethodName: "GetHotKeys",
+			Handler:    _Goods_GetHotKeys_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRoomGoods",
+			Handler:       _Goods_WatchRoomGoods_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WarmUpRoom",
+			Handler:       _Goods_WarmUpRoom_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "goods.proto",
 }
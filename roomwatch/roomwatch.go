@@ -0,0 +1,317 @@
+package roomwatch
+
+import (
+	"context"
+	"fmt"
+	"goods_srv/dao/redis"
+	"goods_srv/logger"
+	"goods_srv/metrics"
+	"goods_srv/proto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// 直播间商品事件
+// 1. 写操作发布事件时先追加到直播间的 Redis Stream，Stream 中的消息 ID 作为事件的 ResumeToken，
+//    客户端断线重连时从 Stream 中补发错过的事件
+// 2. 再通过 Redis pub/sub 广播给所有实例，每个实例只订阅一次频道，按直播间分发给本实例的订阅者
+// 3. 每个订阅者有固定大小的缓冲区，消费太慢导致缓冲区满时丢弃事件并通知订阅者落后，由订阅者重新发送快照
+
+const (
+	// channel 直播间商品事件的 Redis 频道
+	channel = "room_goods_events"
+
+	// defaultStreamMaxLen 未配置时每个直播间 Stream 保留的事件数，更早的事件无法通过 ResumeToken 补发
+	defaultStreamMaxLen = 1000
+	// streamTTL 直播间 Stream 的过期时间，直播间长时间没有事件时自动删除
+	streamTTL = 24 * time.Hour
+
+	// 订阅断开后的重连退避时间
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+
+	// defaultBufferSize 未配置时每个订阅者的事件缓冲区大小
+	defaultBufferSize = 64
+)
+
+var (
+	mu       sync.RWMutex
+	watchers = make(map[int64]map[*Watcher]struct{}) // 直播间 ID 到本实例订阅者的映射
+
+	bufferSize   = defaultBufferSize
+	streamMaxLen = int64(defaultStreamMaxLen)
+
+	watchersGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "room_watch",
+		Name:      "watchers",
+		Help:      "本实例当前的直播间商品订阅数",
+	}, func() float64 {
+		mu.RLock()
+		defer mu.RUnlock()
+		n := 0
+		for _, ws := range watchers {
+			n += len(ws)
+		}
+		return float64(n)
+	})
+	laggedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "room_watch",
+		Name:      "lagged_total",
+		Help:      "订阅者缓冲区满导致丢弃事件的次数",
+	})
+)
+
+// Watcher 直播间商品事件的订阅者
+type Watcher struct {
+	roomId int64
+	events chan *proto.RoomGoodsEvent
+	lagged chan struct{}
+}
+
+// Events 返回事件通道
+func (w *Watcher) Events() <-chan *proto.RoomGoodsEvent {
+	return w.events
+}
+
+// Lagged 订阅者丢失了事件时收到通知，需要重新发送快照
+func (w *Watcher) Lagged() <-chan struct{} {
+	return w.lagged
+}
+
+// Drain 丢弃缓冲区中的事件，重新发送快照前调用
+func (w *Watcher) Drain() {
+	for {
+		select {
+		case <-w.events:
+		default:
+			return
+		}
+	}
+}
+
+// Close 取消订阅
+func (w *Watcher) Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(watchers[w.roomId], w)
+	if len(watchers[w.roomId]) == 0 {
+		delete(watchers, w.roomId)
+	}
+}
+
+// Start 订阅直播间商品事件频道
+// size 为每个订阅者的缓冲区大小，maxLen 为每个直播间 Stream 保留的事件数，0 表示使用默认值
+func Start(ctx context.Context, size int, maxLen int64) {
+	if size > 0 {
+		bufferSize = size
+	}
+	if maxLen > 0 {
+		streamMaxLen = maxLen
+	}
+	metrics.MustRegister(watchersGauge, laggedTotal)
+	go subscribeLoop(ctx)
+}
+
+// Watch 订阅直播间的商品事件，使用完后需要调用 Close
+func Watch(roomId int64) *Watcher {
+	w := &Watcher{
+		roomId: roomId,
+		events: make(chan *proto.RoomGoodsEvent, bufferSize),
+		lagged: make(chan struct{}, 1),
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if watchers[roomId] == nil {
+		watchers[roomId] = make(map[*Watcher]struct{})
+	}
+	watchers[roomId][w] = struct{}{}
+	return w
+}
+
+// Publish 发布直播间商品事件，先写入直播间的 Stream 生成 ResumeToken，再广播给所有实例
+func Publish(ctx context.Context, ev *proto.RoomGoodsEvent) error {
+	data, err := gproto.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	key := streamKey(ev.RoomId)
+	id, err := redis.GetClient().XAdd(ctx, &goredis.XAddArgs{
+		Stream: key,
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"data": data},
+	}).Result()
+	if err != nil {
+		return err
+	}
+	redis.GetClient().Expire(ctx, key, streamTTL)
+
+	ev = gproto.Clone(ev).(*proto.RoomGoodsEvent)
+	ev.ResumeToken = id
+	if data, err = gproto.Marshal(ev); err != nil {
+		return err
+	}
+	return redis.GetClient().Publish(ctx, channel, data).Err()
+}
+
+// Replay 返回 token 之后的事件，token 已经不在 Stream 中（被裁剪或无效）时 ok 为 false，需要重新发送快照
+func Replay(ctx context.Context, roomId int64, token string) (events []*proto.RoomGoodsEvent, ok bool, err error) {
+	if _, _, valid := parseToken(token); !valid {
+		return nil, false, nil
+	}
+	key := streamKey(roomId)
+	// token 对应的事件必须还在 Stream 中，否则 token 之后的部分事件可能已被裁剪
+	first, err := redis.GetClient().XRangeN(ctx, key, token, "+", 1).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(first) == 0 || first[0].ID != token {
+		return nil, false, nil
+	}
+
+	msgs, err := redis.GetClient().XRange(ctx, key, "("+token, "+").Result()
+	if err != nil {
+		return nil, false, err
+	}
+	for _, msg := range msgs {
+		ev, err := decodeStreamMessage(roomId, msg)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to decode room goods event", zap.String("id", msg.ID), zap.Error(err))
+			continue
+		}
+		events = append(events, ev)
+	}
+	return events, true, nil
+}
+
+// LatestToken 返回直播间最新事件的 ResumeToken，没有事件时返回 "0-0"
+func LatestToken(ctx context.Context, roomId int64) (string, error) {
+	msgs, err := redis.GetClient().XRevRangeN(ctx, streamKey(roomId), "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(msgs) == 0 {
+		return "0-0", nil
+	}
+	return msgs[0].ID, nil
+}
+
+// After 判断 token a 是否在 token b 之后
+func After(a, b string) bool {
+	ams, aseq, aok := parseToken(a)
+	bms, bseq, bok := parseToken(b)
+	if !aok || !bok {
+		return true
+	}
+	return ams > bms || (ams == bms && aseq > bseq)
+}
+
+// subscribeLoop 订阅事件频道，断开后按指数退避重连
+func subscribeLoop(ctx context.Context) {
+	backoff := minBackoff
+	connected := false
+	for ctx.Err() == nil {
+		ps := redis.GetClient().Subscribe(ctx, channel)
+		if _, err := ps.Receive(ctx); err != nil {
+			logger.FromContext(ctx).Error("Failed to subscribe room goods event channel", zap.Error(err))
+			ps.Close()
+			time.Sleep(backoff)
+			backoff = min(backoff*2, maxBackoff)
+			continue
+		}
+		backoff = minBackoff
+		if connected {
+			// 断开期间可能错过事件，通知所有订阅者重新发送快照
+			logger.FromContext(ctx).Warn("Room goods event channel reconnected, resync all watchers")
+			notifyAllLagged()
+		}
+		connected = true
+
+		for {
+			msg, err := ps.ReceiveMessage(ctx)
+			if err != nil {
+				logger.FromContext(ctx).Warn("Room goods event subscription broken", zap.Error(err))
+				break
+			}
+			dispatch(ctx, msg.Payload)
+		}
+		ps.Close()
+	}
+}
+
+// dispatch 将事件分发给本实例订阅了该直播间的订阅者，缓冲区满时通知订阅者落后
+func dispatch(ctx context.Context, payload string) {
+	var ev proto.RoomGoodsEvent
+	if err := gproto.Unmarshal([]byte(payload), &ev); err != nil {
+		logger.FromContext(ctx).Error("Failed to unmarshal room goods event", zap.Error(err))
+		return
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	for w := range watchers[ev.RoomId] {
+		select {
+		case w.events <- &ev:
+		default:
+			laggedTotal.Inc()
+			notifyLagged(w)
+		}
+	}
+}
+
+// notifyAllLagged 通知本实例的所有订阅者落后
+func notifyAllLagged() {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, ws := range watchers {
+		for w := range ws {
+			notifyLagged(w)
+		}
+	}
+}
+
+func notifyLagged(w *Watcher) {
+	select {
+	case w.lagged <- struct{}{}:
+	default:
+	}
+}
+
+// decodeStreamMessage 解析 Stream 中的事件，消息 ID 作为 ResumeToken
+func decodeStreamMessage(roomId int64, msg goredis.XMessage) (*proto.RoomGoodsEvent, error) {
+	data, ok := msg.Values["data"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing data field")
+	}
+	var ev proto.RoomGoodsEvent
+	if err := gproto.Unmarshal([]byte(data), &ev); err != nil {
+		return nil, err
+	}
+	ev.RoomId = roomId
+	ev.ResumeToken = msg.ID
+	return &ev, nil
+}
+
+// streamKey 直播间事件 Stream 的 key
+func streamKey(roomId int64) string {
+	return fmt.Sprintf("room_goods_events_%d", roomId)
+}
+
+// parseToken 解析 Stream 消息 ID（毫秒时间戳-序号）
+func parseToken(token string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(token, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err1 := strconv.ParseUint(msPart, 10, 64)
+	seq, err2 := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq, err1 == nil && err2 == nil
+}
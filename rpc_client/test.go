@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"goods_srv/proto" // 引入定义了 gRPC 服务的 proto 文件
 	"log"
 	"sync"
@@ -71,6 +72,118 @@ func TestUpdateGoodsDetail(wg *sync.WaitGroup, index int) {
 		log.Printf("Update response: %+v", resp) // 如果调用成功，记录响应
 	}
 }
+// 测试并发读写下缓存与数据库的一致性
+// 多个协程不断读取同一商品、回源写缓存，同时依次更新价格；
+// 更新结束并等待延迟双删等后台操作完成后，读到的价格应等于最后一次写入的价格
+func TestUpdateConsistency(goodsId int64, rounds int) {
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	// 启动并发读协程，制造"读到旧数据后写回缓存"的竞争
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					client.GetGoodsDetail(context.Background(), &proto.GetGoodsDetailReq{GoodsId: goodsId, UserId: 1})
+				}
+			}
+		}()
+	}
+
+	// 依次更新价格（单位：分）
+	var lastPrice int64
+	for i := 1; i <= rounds; i++ {
+		lastPrice = int64(10000 + i*100)
+		_, err := client.UpdateGoodsDetail(context.Background(), &proto.UpdateGoodsDetailReq{GoodsId: goodsId, Price: lastPrice})
+		if err != nil {
+			log.Printf("Error calling UpdateGoodsDetail: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	// 等待延迟双删完成
+	time.Sleep(2 * time.Second)
+
+	resp, err := client.GetGoodsDetail(context.Background(), &proto.GetGoodsDetailReq{GoodsId: goodsId, UserId: 1})
+	if err != nil {
+		log.Printf("Error calling GetGoodsDetail: %v", err)
+		return
+	}
+	want := fmt.Sprintf("%.2f", float64(lastPrice)/100)
+	if resp.Price != want {
+		log.Printf("Inconsistent cache for GoodsId: %d, got price %s, want %s", goodsId, resp.Price, want)
+	} else {
+		log.Printf("Cache consistent for GoodsId: %d, price %s", goodsId, resp.Price)
+	}
+}
+
+// 测试 BatchGetGoodsDetail 方法，打印每个商品的查询结果
+func TestBatchGetGoodsDetail(goodsIds []int64) {
+	resp, err := client.BatchGetGoodsDetail(context.Background(), &proto.BatchGetGoodsDetailReq{
+		GoodsIds: goodsIds,
+		UserId:   1,
+	})
+	if err != nil {
+		log.Printf("Error calling BatchGetGoodsDetail: %v", err)
+		return
+	}
+	for _, item := range resp.Data {
+		if item.NotFound {
+			log.Printf("GoodsId: %d not found", item.GoodsId)
+			continue
+		}
+		log.Printf("GoodsId: %d detail: %+v", item.GoodsId, item.Detail)
+	}
+}
+
+// 测试 WarmUpRoom 方法，打印直播间缓存预热进度
+func TestWarmUpRoom(roomId int64) {
+	stream, err := client.WarmUpRoom(context.Background(), &proto.WarmUpRoomReq{RoomId: roomId})
+	if err != nil {
+		log.Printf("Error calling WarmUpRoom: %v", err)
+		return
+	}
+	for {
+		progress, err := stream.Recv()
+		if err != nil {
+			log.Printf("Error receiving warm-up progress: %v", err)
+			return
+		}
+		log.Printf("Warm-up progress: %+v", progress)
+		if progress.Done {
+			return
+		}
+	}
+}
+
+// TestWatchRoomGoods 订阅直播间商品变更，断线后使用最后收到的 ResumeToken 重连补发错过的事件
+func TestWatchRoomGoods(roomId int64) {
+	var resumeToken string
+	for {
+		stream, err := client.WatchRoomGoods(context.Background(), &proto.WatchRoomGoodsReq{RoomId: roomId, ResumeToken: resumeToken})
+		if err != nil {
+			log.Printf("Error calling WatchRoomGoods: %v", err)
+			return
+		}
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				log.Printf("Error receiving room goods event: %v, reconnecting", err)
+				break
+			}
+			log.Printf("Room goods event: %+v", ev)
+			resumeToken = ev.ResumeToken
+		}
+		time.Sleep(time.Second)
+	}
+}
+
 func main() {
 	defer conn.Close()    // 程序结束时关闭 gRPC 客户端连接
 	var wg sync.WaitGroup // 使用 WaitGroup 等待所有协程完成
@@ -82,6 +195,18 @@ func main() {
 		//go TestUpdateGoodsDetail(&wg, i)
 	}
 	wg.Wait() // 等待所有协程完成
+
+	// 测试并发读写下的缓存一致性，可分别在不同的 cache.write_strategy 配置下运行
+	//TestUpdateConsistency(1001, 20)
+
+	// 开播前预热直播间商品缓存
+	//TestWarmUpRoom(1)
+
+	// 批量获取商品详情
+	//TestBatchGetGoodsDetail([]int64{1001, 1002, 1003})
+
+	// 订阅直播间商品变更
+	//TestWatchRoomGoods(1)
 }
 
 var num int = 100 // 全局变量，初始值为 100（未在代码中使用）
@@ -0,0 +1,12 @@
+CREATE TABLE `xx_goods_change_log` (
+                         `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                         `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '修改时间',
+                         `goods_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '商品id',
+                         `action` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '操作类型：create新增update修改delete删除',
+                         `old_value` TEXT COMMENT '修改前的字段值（JSON）',
+                         `new_value` TEXT COMMENT '修改后的字段值（JSON）',
+                         `operator` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '操作人',
+                         `reason` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '修改原因',
+                         `schedule_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '产生该修改的定时改价id，0表示不是定时改价',
+                         INDEX (goods_id)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '商品修改日志表';
@@ -0,0 +1,15 @@
+CREATE TABLE `xx_goods_price_schedule` (
+                         `id` BIGINT(20) UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+                         `create_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                         `update_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+                         `goods_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '商品id',
+                         `price` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '生效期间的售价（分）',
+                         `original_price` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '生效时的原售价（分）',
+                         `effective_from` DATETIME NOT NULL COMMENT '生效时间',
+                         `effective_to` DATETIME NULL DEFAULT NULL COMMENT '结束时间，为空表示不恢复',
+                         `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '状态：0待生效1生效中2已结束3已取消',
+                         `operator` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建人',
+                         `reason` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '改价原因',
+                         INDEX (goods_id),
+                         INDEX (status, effective_from)
+)ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '商品定时改价表';
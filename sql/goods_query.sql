@@ -11,12 +11,13 @@ CREATE TABLE `xx_goods_query` (
                          `category_id` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '类目id',
                          `brand_name` VARCHAR(255) NOT NULL COMMENT '品牌名',
                          `code` VARCHAR(64) NOT NULL COMMENT '码',
-                         `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '是否上架：0上架1下架',
+                         `status` tinyint(4) UNSIGNED NOT NULL DEFAULT '0' COMMENT '商品状态：0上架1下架2草稿3审核中4已删除',
                          `title` VARCHAR(255) NOT NULL COMMENT '名称',
                          `market_price` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '市场价/划线价（分）',
                          `price` BIGINT(20) UNSIGNED NOT NULL DEFAULT '0' COMMENT '售价（分）',
                          `brief` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '简介',
                          UNIQUE (goods_id),
+                         UNIQUE (code),
                          INDEX (category_id),
                          INDEX (is_del)
 )ENGINE=INNODB DEFAULT CHARSET=utf8mb4 COMMENT = '商品查询表';